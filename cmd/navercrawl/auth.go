@@ -0,0 +1,314 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"text/tabwriter"
+
+	"naverCafeCrawler/internal/cafeurl"
+	"naverCafeCrawler/internal/credstore"
+	"naverCafeCrawler/internal/session"
+)
+
+// 암호화된 세션 저장소 플래그
+type storeFlags struct {
+	path    string
+	keyFile string
+}
+
+func (f *storeFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.path, "store", envString("NAVER_STORE", credstore.DefaultPath()), "암호화된 세션 저장소 파일 (NAVER_STORE)")
+	fs.StringVar(&f.keyFile, "key-file", os.Getenv("NAVER_STORE_KEY_FILE"), "저장소 키 파일, 비우면 "+credstore.PassphraseEnv+" 또는 입력한 암호 사용 (NAVER_STORE_KEY_FILE)")
+}
+
+// 저장소 열기
+// create 가 true 이고 저장소가 없으면 새로 만듭니다. 이때 -key-file 이 없는 파일이면 무작위 키를 만들어 기록합니다.
+func (f *storeFlags) open(create bool) (*credstore.Store, error) {
+	_, err := os.Stat(f.path)
+	exists := err == nil
+	if !exists && !create {
+		return nil, fmt.Errorf("세션 저장소 %s 이(가) 없습니다. 'navercrawl auth add' 로 세션을 먼저 추가하세요", f.path)
+	}
+
+	if !exists && f.keyFile != "" {
+		if _, err := os.Stat(f.keyFile); os.IsNotExist(err) {
+			if _, err := credstore.GenerateKeyFile(f.keyFile); err != nil {
+				return nil, err
+			}
+			log.Printf("🔑 새 키 파일을 %s 에 만들었습니다. 이 파일을 잃어버리면 저장소를 열 수 없습니다.", f.keyFile)
+		}
+	}
+	secret, err := credstore.Secret(f.keyFile)
+	if errors.Is(err, credstore.ErrNoSecret) {
+		if exists {
+			secret, err = readPassphrase(fmt.Sprintf("저장소 %s 암호: ", f.path))
+		} else {
+			secret, err = newPassphrase(fmt.Sprintf("새 저장소 %s 암호: ", f.path))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return credstore.Open(f.path, secret)
+}
+
+// 터미널에서 입력받는 암호 (여러 번 읽을 수 있도록 하나의 버퍼를 공유)
+var stdin = bufio.NewReader(os.Stdin)
+
+// 화면에 표시하지 않고 암호 입력받기
+func readPassphrase(prompt string) ([]byte, error) {
+	if fi, err := os.Stdin.Stat(); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+		return nil, fmt.Errorf("%w: -key-file 이나 %s 환경 변수를 지정하세요", credstore.ErrNoSecret, credstore.PassphraseEnv)
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	if stty("-echo") == nil {
+		defer stty("echo")
+	}
+	line, err := stdin.ReadString('\n')
+	fmt.Fprintln(os.Stderr)
+	if err == io.EOF && line == "" {
+		return nil, fmt.Errorf("%w: -key-file 이나 %s 환경 변수를 지정하세요", credstore.ErrNoSecret, credstore.PassphraseEnv)
+	}
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("암호 입력 실패: %v", err)
+	}
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
+// 표준 입력에서 쿠키 문자열 읽기
+// 터미널이면 화면에 표시하지 않고 한 줄을 입력받고, 파이프나 파일이면 전체를 읽습니다.
+func readCookie() (string, error) {
+	var data string
+	if fi, err := os.Stdin.Stat(); err == nil && fi.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "쿠키 문자열 (NID_AUT=...; NID_SES=...): ")
+		if stty("-echo") == nil {
+			defer stty("echo")
+		}
+		line, err := stdin.ReadString('\n')
+		fmt.Fprintln(os.Stderr)
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("쿠키 입력 실패: %v", err)
+		}
+		data = line
+	} else {
+		b, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", fmt.Errorf("쿠키 읽기 실패: %v", err)
+		}
+		data = string(b)
+	}
+	cookie := strings.TrimSpace(data)
+	if cookie == "" {
+		return "", fmt.Errorf("쿠키 문자열이 없습니다. -cookie-file 로 쿠키 파일을 주거나 표준 입력으로 쿠키 문자열을 넘기세요")
+	}
+	return cookie, nil
+}
+
+// 새 암호를 두 번 입력받아 확인
+func newPassphrase(prompt string) ([]byte, error) {
+	secret, err := readPassphrase(prompt)
+	if err != nil {
+		return nil, err
+	}
+	if len(secret) < credstore.MinPassphrase {
+		return nil, fmt.Errorf("암호는 %d자 이상이어야 합니다", credstore.MinPassphrase)
+	}
+	confirm, err := readPassphrase("암호 확인: ")
+	if err != nil {
+		return nil, err
+	}
+	if string(confirm) != string(secret) {
+		return nil, fmt.Errorf("암호가 일치하지 않습니다")
+	}
+	return secret, nil
+}
+
+// 터미널 입력 표시 설정 (stty 가 없는 환경에서는 실패하고 입력이 화면에 보임)
+func stty(arg string) error {
+	cmd := exec.Command("stty", arg)
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+func runAuthAdd(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth add", flag.ContinueOnError)
+	var f storeFlags
+	f.register(fs)
+	name := fs.String("name", "", "세션 이름 (크롤링할 때 -session 으로 지정)")
+	cookieFile := fs.String("cookie-file", "", "cookies.txt 또는 브라우저 JSON 쿠키 파일, 비우면 쿠키 문자열을 표준 입력에서 읽음")
+	cafes := fs.String("cafes", "", "세션 풀에서 이 세션으로 접근할 카페 ID, 쉼표로 구분 (비우면 모든 카페)")
+	replace := fs.Bool("replace", false, "같은 이름의 세션이 있으면 바꿈")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "사용법: navercrawl auth add -name <이름> [-cookie-file <파일>] [플래그]")
+		fmt.Fprintln(fs.Output(), "-cookie-file 이 없으면 브라우저 요청 헤더에서 복사한 쿠키 문자열을 표준 입력에서 읽습니다.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		*name = fs.Arg(0)
+	}
+	if err := require(fs, "name", *name); err != nil {
+		return err
+	}
+	cafeIDs := splitList(*cafes)
+	for _, id := range cafeIDs {
+		if !cafeurl.IsNumeric(id) {
+			return fmt.Errorf("-cafes 에는 숫자 카페 ID 를 적어야 합니다: %q", id)
+		}
+	}
+
+	var (
+		sess *session.Session
+		err  error
+	)
+	if *cookieFile != "" {
+		sess, err = session.Load(*cookieFile)
+	} else {
+		// 명령줄 인자로 받으면 셸 기록과 프로세스 목록에 쿠키가 남으므로 표준 입력에서 읽음
+		var cookie string
+		if cookie, err = readCookie(); err == nil {
+			sess, err = session.FromHeader(cookie)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if !sess.LoggedIn() {
+		log.Printf("⚠️ 로그인 쿠키(%s, %s)가 없습니다. 로그인한 브라우저에서 내보낸 쿠키인지 확인하세요.",
+			session.AuthCookie, session.SessionCookie)
+	}
+
+	store, err := f.open(true)
+	if err != nil {
+		return err
+	}
+	if _, ok := store.Get(*name); ok && !*replace {
+		return fmt.Errorf("세션 %s 이(가) 이미 있습니다 (바꾸려면 -replace)", *name)
+	}
+	store.Put(credstore.Entry{Name: *name, Cookies: sess.Netscape(), Cafes: cafeIDs})
+	if err := store.Save(); err != nil {
+		return err
+	}
+
+	log.Printf("🔐 세션 %s 을(를) %s 에 저장했습니다.", *name, store.Path())
+	if *cookieFile != "" {
+		log.Printf("🧹 평문 쿠키 파일 %s 은(는) 더 이상 필요 없으면 삭제하세요.", *cookieFile)
+	}
+	return nil
+}
+
+func runAuthList(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth list", flag.ContinueOnError)
+	var f storeFlags
+	f.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	store, err := f.open(false)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "이름\t카페\t로그인\t만료\t수정")
+	for _, e := range store.Entries() {
+		loggedIn, expires := "", "-"
+		if s, err := e.Session(); err != nil {
+			loggedIn = "오류"
+		} else {
+			if s.LoggedIn() {
+				loggedIn = "✓"
+			}
+			if t := s.Expires(); !t.IsZero() {
+				expires = t.Format("2006-01-02 15:04")
+			}
+		}
+		cafes := strings.Join(e.Cafes, ",")
+		if cafes == "" {
+			cafes = "전체"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", e.Name, cafes, loggedIn, expires, e.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+	return tw.Flush()
+}
+
+func runAuthRemove(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth remove", flag.ContinueOnError)
+	var f storeFlags
+	f.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "사용법: navercrawl auth remove [플래그] <이름>...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("지울 세션 이름이 필요합니다 ('navercrawl auth remove -h' 참고)")
+	}
+	store, err := f.open(false)
+	if err != nil {
+		return err
+	}
+	for _, name := range fs.Args() {
+		if !store.Remove(name) {
+			return fmt.Errorf("%w: %s", credstore.ErrNotFound, name)
+		}
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+	log.Printf("🗑️ 세션 %s 을(를) 지웠습니다.", strings.Join(fs.Args(), ", "))
+	return nil
+}
+
+func runAuthRotate(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("auth rotate", flag.ContinueOnError)
+	var f storeFlags
+	f.register(fs)
+	newKeyFile := fs.String("new-key-file", "", "새 키 파일, 없는 파일이면 무작위 키를 만들어 기록 (비우면 새 암호 입력)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	store, err := f.open(false)
+	if err != nil {
+		return err
+	}
+
+	var secret []byte
+	if *newKeyFile != "" {
+		if _, err := os.Stat(*newKeyFile); os.IsNotExist(err) {
+			secret, err = credstore.GenerateKeyFile(*newKeyFile)
+			if err != nil {
+				return err
+			}
+			log.Printf("🔑 새 키 파일을 %s 에 만들었습니다.", *newKeyFile)
+		} else if secret, err = credstore.ReadKeyFile(*newKeyFile); err != nil {
+			return err
+		}
+	} else if secret, err = newPassphrase("새 암호: "); err != nil {
+		return err
+	}
+
+	if err := store.Rekey(secret); err != nil {
+		return err
+	}
+	if err := store.Save(); err != nil {
+		return err
+	}
+	log.Printf("🔄 저장소 %s 을(를) 새 키로 다시 암호화했습니다.", store.Path())
+	if *newKeyFile != "" {
+		log.Printf("💡 이제 -key-file %s (또는 NAVER_STORE_KEY_FILE) 로 저장소를 여세요.", *newKeyFile)
+	}
+	return nil
+}
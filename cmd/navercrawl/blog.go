@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"naverCafeCrawler/internal/crawling"
+)
+
+func runBlogPosts(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("blog posts", flag.ContinueOnError)
+	var f commonFlags
+	f.register(fs, "output_blog")
+	blogID := fs.String("blog", os.Getenv("NAVER_BLOG_ID"), "블로그 ID (NAVER_BLOG_ID)")
+	maxPages := fs.Int("max-pages", envInt("NAVER_MAX_PAGES", 2), "크롤링할 목록 페이지 수 (NAVER_MAX_PAGES)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "blog", *blogID); err != nil {
+		return err
+	}
+	opts, err := f.options(true)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("🎯 대상 블로그: %s", *blogID)
+	log.Printf("📄 크롤링 페이지 수: %d", *maxPages)
+
+	posts, err := crawling.New(opts...).CrawlBlog(ctx, *blogID, *maxPages)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 크롤링 완료! 총 %d개 블로그 게시글 수집\n", len(posts))
+	return nil
+}
+
+func runBlogPost(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("blog post", flag.ContinueOnError)
+	var f commonFlags
+	f.register(fs, "output_blog")
+	blogID := fs.String("blog", os.Getenv("NAVER_BLOG_ID"), "블로그 ID (NAVER_BLOG_ID)")
+	logNo := fs.String("post", "", "게시글 번호 (logNo)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "blog", *blogID, "post", *logNo); err != nil {
+		return err
+	}
+	opts, err := f.options(true)
+	if err != nil {
+		return err
+	}
+
+	post, err := crawling.New(opts...).CrawlBlogPost(ctx, *blogID, *logNo)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ [%s] %s (댓글 %d개)\n", post.ID, post.Title, len(post.Comments))
+	return nil
+}
@@ -0,0 +1,507 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"naverCafeCrawler/internal/cafeurl"
+	"naverCafeCrawler/internal/crawling"
+	"naverCafeCrawler/internal/credstore"
+	"naverCafeCrawler/internal/ratelimit"
+	"naverCafeCrawler/internal/session"
+)
+
+// 카페 명령 공통 플래그
+type cafeFlags struct {
+	commonFlags
+	sessionFlags
+	cafeID    string
+	skipCheck bool
+	maxPages  int
+	pageSize  int
+	stream    bool
+	print     bool
+}
+
+func (f *cafeFlags) register(fs *flag.FlagSet) {
+	f.commonFlags.register(fs, "output")
+	f.sessionFlags.register(fs)
+	fs.StringVar(&f.cafeID, "cafe", os.Getenv("NAVER_CAFE_ID"), "카페 ID, 별칭 또는 카페/게시판 주소 (NAVER_CAFE_ID)")
+	fs.BoolVar(&f.skipCheck, "skip-session-check", false, "크롤링 전 로그인 상태 확인 요청을 보내지 않음")
+	fs.IntVar(&f.maxPages, "max-pages", envInt("NAVER_MAX_PAGES", 0), "최대 페이지 수, 0은 무제한 (NAVER_MAX_PAGES)")
+	fs.IntVar(&f.pageSize, "page-size", envInt("NAVER_PAGE_SIZE", 10), "페이지당 게시글 수 (NAVER_PAGE_SIZE)")
+	fs.BoolVar(&f.stream, "stream-only", envBool("NAVER_STREAM_ONLY"), "결과를 메모리에 모으지 않고 파일로만 기록 (NAVER_STREAM_ONLY)")
+	fs.BoolVar(&f.print, "print", false, "수집한 게시글을 콘솔에 출력")
+}
+
+// 로그인 세션으로 크롤러를 만들고 로그인 상태 확인
+func (f *cafeFlags) crawler(ctx context.Context) (*crawling.Crawler, error) {
+	opts, err := f.options(false)
+	if err != nil {
+		return nil, err
+	}
+	sessionOpts, err := f.sessionOptions(true)
+	if err != nil {
+		return nil, err
+	}
+
+	crawler := crawling.New(append(opts, sessionOpts...)...)
+	if !f.skipCheck {
+		if err := crawler.CheckSession(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return crawler, nil
+}
+
+// 로그인 세션 플래그 (-session, -cookie-file, -cookie, -session-rate, -session-burst)
+type sessionFlags struct {
+	store        storeFlags
+	session      string
+	cookie       string
+	cookieFile   string
+	sessionRate  float64
+	sessionBurst int
+
+	accounts []*session.Account
+	creds    *credstore.Store // -session 으로 세션을 읽은 저장소
+}
+
+func (f *sessionFlags) register(fs *flag.FlagSet) {
+	f.store.register(fs)
+	fs.StringVar(&f.session, "session", os.Getenv("NAVER_SESSION"), "세션 저장소('navercrawl auth add')에 저장한 세션 이름, 쉼표로 여러 개를 주면 세션 풀로 사용, -cookie-file 보다 우선 (NAVER_SESSION)")
+	fs.StringVar(&f.cookie, "cookie", os.Getenv("NAVER_COOKIE"), "로그인 쿠키, 셸 기록에 남으므로 -session 권장 (NAVER_COOKIE, 권장하지 않음)")
+	fs.StringVar(&f.cookieFile, "cookie-file", os.Getenv("NAVER_COOKIE_FILE"), "cookies.txt 또는 브라우저 JSON 쿠키 파일, 쉼표로 여러 개를 주면 세션 풀로 사용, -cookie 보다 우선 (NAVER_COOKIE_FILE, 권장하지 않음)")
+	fs.Float64Var(&f.sessionRate, "session-rate", envFloat("NAVER_SESSION_RATE", 0), "세션 풀에서 세션마다 초당 최대 요청 수, -rate 의 전체 상한과 함께 적용, 0 이하는 세션별로 제한하지 않음 (NAVER_SESSION_RATE)")
+	fs.IntVar(&f.sessionBurst, "session-burst", envInt("NAVER_SESSION_BURST", 1), "세션 풀에서 세션마다 연속으로 허용할 최대 요청 수 (NAVER_SESSION_BURST)")
+}
+
+// 세션 플래그로 로그인 세션을 읽어 크롤러 옵션으로 변환
+// 세션(-session)이나 쿠키 파일을 여러 개 주면 세션 풀로 요청을 나눠 보내며, 모든 세션이 -rate 의 전체 상한을 나눠 씁니다.
+// -session-rate 를 주면 세션마다 그 제한도 함께 기다립니다.
+// 세션을 지정하지 않았으면 required 일 때만 오류를 반환합니다.
+func (f *sessionFlags) sessionOptions(required bool) ([]crawling.Option, error) {
+	var err error
+	names, files := splitList(f.session), splitList(f.cookieFile)
+	switch {
+	case len(names) > 0:
+		if f.creds, err = f.store.open(false); err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			entry, ok := f.creds.Get(name)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s ('navercrawl auth list' 참고)", credstore.ErrNotFound, name)
+			}
+			s, err := entry.Session()
+			if err != nil {
+				return nil, err
+			}
+			f.accounts = append(f.accounts, &session.Account{Name: name, Session: s, Cafes: entry.Cafes})
+		}
+	case len(files) > 0:
+		warnCookieEnv("NAVER_COOKIE_FILE", f.cookieFile)
+		for _, file := range files {
+			s, err := session.Load(file)
+			if err != nil {
+				return nil, err
+			}
+			f.accounts = append(f.accounts, &session.Account{
+				Name:    strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+				Session: s,
+			})
+		}
+	case f.cookie != "":
+		warnCookieEnv("NAVER_COOKIE", f.cookie)
+		s, err := session.FromHeader(f.cookie)
+		if err != nil {
+			return nil, err
+		}
+		f.accounts = append(f.accounts, &session.Account{Name: "cookie", Session: s})
+	case required:
+		return nil, fmt.Errorf("로그인 세션이 필요합니다 ('navercrawl auth add' 로 저장한 세션을 -session 이나 NAVER_SESSION 으로 지정하거나 -cookie-file, -cookie 사용)")
+	default:
+		return nil, nil
+	}
+
+	if len(f.accounts) == 1 {
+		return []crawling.Option{crawling.WithSession(f.accounts[0].Session)}, nil
+	}
+	if f.sessionRate > 0 {
+		for _, a := range f.accounts {
+			a.Limiter = ratelimit.New(f.sessionRate, f.sessionBurst)
+		}
+	}
+	return []crawling.Option{crawling.WithSessionPool(session.NewPool(f.accounts...))}, nil
+}
+
+// 쿠키를 환경 변수에서 읽었으면 세션 저장소를 쓰도록 경고
+// 평문 쿠키가 .env 나 셸 설정에 남으므로 NAVER_COOKIE, NAVER_COOKIE_FILE 은 더 이상 권장하지 않습니다.
+func warnCookieEnv(env, value string) {
+	if v := os.Getenv(env); v == "" || v != value {
+		return
+	}
+	log.Printf("⚠️ %s 환경 변수는 더 이상 권장하지 않습니다. 'navercrawl auth add' 로 세션 저장소에 저장하고 -session(NAVER_SESSION)으로 지정하세요.", env)
+}
+
+// 서버가 갱신한 쿠키를 세션 저장소나 cookies.txt 에 다시 기록
+func (f *sessionFlags) saveSession() {
+	for _, a := range f.accounts {
+		if f.creds != nil {
+			saved, err := f.creds.Update(a.Name, a.Session)
+			if err != nil {
+				log.Printf("⚠️ 세션 %s 의 갱신된 쿠키 저장 실패: %v", a.Name, err)
+			} else if saved {
+				log.Printf("🍪 세션 %s 의 갱신된 쿠키를 %s 에 저장했습니다.", a.Name, f.creds.Path())
+			}
+			continue
+		}
+		saved, err := a.Session.SaveIfChanged()
+		if err != nil {
+			log.Printf("⚠️ 갱신된 쿠키 저장 실패: %v", err)
+		} else if saved {
+			log.Printf("🍪 갱신된 쿠키를 %s 에 저장했습니다.", a.Session.Path())
+		}
+	}
+}
+
+// -cafe 값(카페 ID, 별칭 또는 주소)을 숫자 카페 ID 로 바꾸고, 주소에서 읽은 식별자를 반환
+func (f *cafeFlags) resolve(ctx context.Context, crawler *crawling.Crawler) (cafeurl.Ref, error) {
+	ref, err := crawler.ResolveCafeURL(ctx, f.cafeID)
+	if err != nil {
+		return cafeurl.Ref{}, err
+	}
+	if ref.CafeID != f.cafeID {
+		log.Printf("🔗 %s → 카페 ID %s", f.cafeID, ref.CafeID)
+	}
+	f.cafeID = ref.CafeID
+	return ref, nil
+}
+
+func runCafeBoard(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe board", flag.ContinueOnError)
+	var f cafeFlags
+	f.register(fs)
+	boardID := fs.String("board", os.Getenv("NAVER_BOARD_ID"), "게시판(메뉴) ID (NAVER_BOARD_ID)")
+	resume := fs.Bool("resume", envBool("NAVER_RESUME"), "체크포인트에서 이어서 크롤링 (NAVER_RESUME)")
+	incremental := fs.Bool("incremental", envBool("NAVER_INCREMENTAL"), "지난 실행 이후 새 게시글만 수집 (NAVER_INCREMENTAL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "cafe", f.cafeID); err != nil {
+		return err
+	}
+	crawler, err := f.crawler(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.saveSession()
+	ref, err := f.resolve(ctx, crawler)
+	if err != nil {
+		return err
+	}
+	// 게시판 주소를 -cafe 로 넘기면 -board 를 생략할 수 있음
+	if *boardID == "" {
+		*boardID = ref.MenuID
+	}
+	if err := require(fs, "board", *boardID); err != nil {
+		return err
+	}
+
+	fmt.Println("🚀 네이버 카페 크롤링 시작...")
+	posts, err := crawler.CrawlBoard(ctx, f.cafeID, *boardID, crawling.BoardOptions{
+		MaxPages:    f.maxPages,
+		PageSize:    f.pageSize,
+		Resume:      *resume,
+		StreamOnly:  f.stream,
+		Incremental: *incremental,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 크롤링 완료! 총 %d개 게시글 수집\n", len(posts))
+	if f.print {
+		printArticles(posts)
+	}
+	return nil
+}
+
+func runCafeSearch(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe search", flag.ContinueOnError)
+	var f cafeFlags
+	f.register(fs)
+	query := fs.String("query", os.Getenv("NAVER_SEARCH_QUERY"), "검색어 (NAVER_SEARCH_QUERY)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "cafe", f.cafeID, "query", *query); err != nil {
+		return err
+	}
+	crawler, err := f.crawler(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.saveSession()
+	if _, err := f.resolve(ctx, crawler); err != nil {
+		return err
+	}
+
+	log.Printf("🎯 대상 카페: %s, 검색어: %s", f.cafeID, *query)
+	posts, err := crawler.CrawlSearch(ctx, f.cafeID, *query, crawling.SearchOptions{
+		MaxPages:   f.maxPages,
+		PageSize:   f.pageSize,
+		StreamOnly: f.stream,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ 검색 크롤링 완료! 총 %d개 게시글 수집\n", len(posts))
+	if f.print {
+		printArticles(posts)
+	}
+	return nil
+}
+
+func runCafeMenus(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe menus", flag.ContinueOnError)
+	var f cafeFlags
+	f.register(fs)
+	asJSON := fs.Bool("json", false, "메뉴 목록을 JSON 으로 출력")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "cafe", f.cafeID); err != nil {
+		return err
+	}
+	crawler, err := f.crawler(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.saveSession()
+	if _, err := f.resolve(ctx, crawler); err != nil {
+		return err
+	}
+
+	menus, err := crawler.ListMenus(ctx, f.cafeID)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(menus)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\t이름\t종류\t폴더\t크롤링")
+	for _, m := range menus {
+		readable := ""
+		if m.Readable {
+			readable = "✓"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", m.ID, m.Name, m.Type, m.FolderName, readable)
+	}
+	return tw.Flush()
+}
+
+func runCafeAll(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe all", flag.ContinueOnError)
+	var f cafeFlags
+	f.register(fs)
+	include := fs.String("include", os.Getenv("NAVER_INCLUDE_BOARDS"), "크롤링할 게시판/폴더 ID 또는 이름, 쉼표로 구분 (NAVER_INCLUDE_BOARDS)")
+	exclude := fs.String("exclude", os.Getenv("NAVER_EXCLUDE_BOARDS"), "제외할 게시판/폴더 ID 또는 이름, 쉼표로 구분 (NAVER_EXCLUDE_BOARDS)")
+	resume := fs.Bool("resume", envBool("NAVER_RESUME"), "게시판마다 체크포인트에서 이어서 크롤링 (NAVER_RESUME)")
+	incremental := fs.Bool("incremental", envBool("NAVER_INCREMENTAL"), "게시판마다 지난 실행 이후 새 게시글만 수집 (NAVER_INCREMENTAL)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := require(fs, "cafe", f.cafeID); err != nil {
+		return err
+	}
+	crawler, err := f.crawler(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.saveSession()
+	if _, err := f.resolve(ctx, crawler); err != nil {
+		return err
+	}
+
+	fmt.Println("🚀 네이버 카페 전체 크롤링 시작...")
+	results, err := crawler.CrawlCafe(ctx, f.cafeID, crawling.CafeOptions{
+		BoardOptions: crawling.BoardOptions{
+			MaxPages:    f.maxPages,
+			PageSize:    f.pageSize,
+			Resume:      *resume,
+			StreamOnly:  f.stream,
+			Incremental: *incremental,
+		},
+		Include: splitList(*include),
+		Exclude: splitList(*exclude),
+	})
+	if len(results) > 0 {
+		printBoardResults(results)
+	}
+	return err
+}
+
+func runCafeArticle(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe article", flag.ContinueOnError)
+	var f cafeFlags
+	f.register(fs)
+	article := fs.String("article", "", "게시글 ID 또는 게시글 주소 (주소이면 -cafe 생략 가능)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "사용법: navercrawl cafe article [플래그] [게시글 ID 또는 주소]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *article == "" {
+		*article = fs.Arg(0)
+	}
+	if err := require(fs, "article", *article); err != nil {
+		return err
+	}
+	crawler, err := f.crawler(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.saveSession()
+
+	// 게시글 주소이면 카페 ID 와 게시글 ID 를 주소에서 읽음
+	articleID := *article
+	if !cafeurl.IsNumeric(articleID) {
+		ref, err := crawler.ResolveCafeURL(ctx, articleID)
+		if err != nil {
+			return err
+		}
+		if ref.ArticleID == "" {
+			return fmt.Errorf("게시글 주소가 아닙니다: %s", articleID)
+		}
+		f.cafeID, articleID = ref.CafeID, ref.ArticleID
+	}
+	if err := require(fs, "cafe", f.cafeID); err != nil {
+		return err
+	}
+	if _, err := f.resolve(ctx, crawler); err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(articleID)
+	if err != nil {
+		return fmt.Errorf("잘못된 게시글 ID: %s", articleID)
+	}
+
+	post, err := crawler.CrawlArticle(ctx, f.cafeID, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ [%d] %s (댓글 %d개)\n", post.ID, post.Title, len(post.Comments))
+	if f.print {
+		printArticles([]crawling.CafeArticle{post})
+	}
+	return nil
+}
+
+func runCafeResolve(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("cafe resolve", flag.ContinueOnError)
+	var (
+		f    commonFlags
+		sess sessionFlags // 비공개 카페를 조회할 때만 필요
+	)
+	f.register(fs, "output")
+	sess.register(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "사용법: navercrawl cafe resolve [플래그] <주소 또는 별칭>...")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return fmt.Errorf("주소가 필요합니다 ('navercrawl cafe resolve -h' 참고)")
+	}
+	opts, err := f.options(false)
+	if err != nil {
+		return err
+	}
+	sessionOpts, err := sess.sessionOptions(false)
+	if err != nil {
+		return err
+	}
+	defer sess.saveSession()
+	crawler := crawling.New(append(opts, sessionOpts...)...)
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "주소\t카페ID\t별칭\t게시판ID\t게시글ID")
+	var failed int
+	for _, raw := range fs.Args() {
+		ref, err := crawler.ResolveCafeURL(ctx, raw)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed++
+			log.Printf("⚠️ %v", err)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", raw, ref.CafeID, ref.Alias, ref.MenuID, ref.ArticleID)
+	}
+	tw.Flush()
+	if failed > 0 {
+		return fmt.Errorf("주소 %d개 중 %d개 해석 실패", fs.NArg(), failed)
+	}
+	return nil
+}
+
+// 게시판별 결과 표 출력
+func printBoardResults(results []crawling.BoardResult) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "게시판\t이름\t게시글\t오류")
+	for _, r := range results {
+		errMsg := ""
+		if r.Err != nil {
+			errMsg = r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\n", r.Menu.ID, r.Menu.Name, r.Articles, errMsg)
+	}
+	tw.Flush()
+}
+
+// 콘솔에 결과 출력
+func printArticles(posts []crawling.CafeArticle) {
+	for _, post := range posts {
+		fmt.Printf("\n📌 [%d] %s\n", post.ID, post.Title)
+		fmt.Printf("👤 작성자: %s (레벨: %s)\n", post.Writer.NickName, post.Writer.LevelName)
+		fmt.Printf("📅 작성일: %s\n", post.WriteDate)
+		fmt.Printf("📊 조회수: %d, 댓글: %d, 좋아요: %d\n", post.ReadCount, post.CommentCount, post.LikeCount)
+
+		// 게시글 내용 출력
+		if post.ContentText != "" {
+			fmt.Printf("\n📝 내용:\n%s\n", post.ContentText)
+		}
+
+		// 댓글 출력
+		if len(post.Comments) > 0 {
+			fmt.Printf("\n💬 댓글 (%d개):\n", len(post.Comments))
+			for _, comment := range post.Comments {
+				fmt.Printf("  - [%s] %s (%s)\n",
+					comment.Writer.NickName,
+					comment.Content,
+					comment.WriteDate)
+			}
+		}
+		fmt.Println("\n" + strings.Repeat("─", 80)) // 구분선
+	}
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"naverCafeCrawler/internal/crawling"
+	"naverCafeCrawler/internal/output"
+	"naverCafeCrawler/internal/ratelimit"
+)
+
+// 모든 하위 명령이 공유하는 플래그
+type commonFlags struct {
+	outDir      string
+	format      string
+	rate        float64
+	ratePerHost float64
+	burst       int
+	concurrency int
+	mediaDir    string
+
+	retries        int
+	retryBaseDelay time.Duration
+	retryMaxDelay  time.Duration
+}
+
+func (f *commonFlags) register(fs *flag.FlagSet, defaultOutDir string) {
+	fs.StringVar(&f.outDir, "out", envString("NAVER_OUTPUT_DIR", defaultOutDir), "결과 저장 디렉토리 (NAVER_OUTPUT_DIR)")
+	fs.StringVar(&f.format, "format", envString("NAVER_OUTPUT_FORMAT", ""), "출력 형식: json, jsonl, csv, sqlite (NAVER_OUTPUT_FORMAT)")
+	fs.Float64Var(&f.rate, "rate", envFloat("NAVER_RATE", crawling.DefaultRequestsPerSecond), "전체 초당 최대 요청 수, 세션 풀을 써도 모든 세션을 합친 상한, 0 이하는 무제한 (NAVER_RATE)")
+	fs.Float64Var(&f.ratePerHost, "rate-per-host", envFloat("NAVER_RATE_PER_HOST", 0), "호스트(카페 API, 블로그, 이미지 서버 등)마다 초당 최대 요청 수, 0 이하는 호스트별로 제한하지 않음 (NAVER_RATE_PER_HOST)")
+	fs.IntVar(&f.burst, "burst", envInt("NAVER_BURST", 1), "연속으로 허용할 최대 요청 수 (NAVER_BURST)")
+	fs.IntVar(&f.concurrency, "concurrency", envInt("NAVER_CONCURRENCY", 3), "동시에 처리할 목록 페이지 수 (NAVER_CONCURRENCY)")
+	fs.StringVar(&f.mediaDir, "media", os.Getenv("NAVER_MEDIA_DIR"), "이미지/동영상/첨부 파일을 내려받을 디렉토리, 비우면 내려받지 않음 (NAVER_MEDIA_DIR)")
+
+	def := crawling.DefaultRetryPolicy
+	fs.IntVar(&f.retries, "retries", envInt("NAVER_RETRIES", def.MaxAttempts-1), "일시적으로 실패한 요청의 재시도 횟수, 0이면 재시도 없음 (NAVER_RETRIES)")
+	fs.DurationVar(&f.retryBaseDelay, "retry-base-delay", envDuration("NAVER_RETRY_BASE_DELAY", def.BaseDelay), "첫 재시도 전 대기 시간, 이후 두 배씩 증가 (NAVER_RETRY_BASE_DELAY)")
+	fs.DurationVar(&f.retryMaxDelay, "retry-max-delay", envDuration("NAVER_RETRY_MAX_DELAY", def.MaxDelay), "재시도 대기 시간 상한 (NAVER_RETRY_MAX_DELAY)")
+}
+
+// 크롤러 옵션으로 변환
+func (f *commonFlags) options(blog bool) ([]crawling.Option, error) {
+	if f.retries < 0 {
+		return nil, fmt.Errorf("-retries 는 0 이상이어야 합니다")
+	}
+	if f.retryBaseDelay < 0 || f.retryMaxDelay < 0 {
+		return nil, fmt.Errorf("-retry-base-delay, -retry-max-delay 는 0 이상이어야 합니다")
+	}
+	opts := []crawling.Option{
+		crawling.WithRateLimiter(ratelimit.New(f.rate, f.burst,
+			ratelimit.WithJitter(crawling.DefaultJitter), ratelimit.WithPerHost(f.ratePerHost, f.burst))),
+		crawling.WithConcurrency(f.concurrency),
+		crawling.WithMediaDir(f.mediaDir),
+		crawling.WithRetryPolicy(crawling.RetryPolicy{
+			MaxAttempts: f.retries + 1,
+			BaseDelay:   f.retryBaseDelay,
+			MaxDelay:    f.retryMaxDelay,
+		}),
+	}
+	if blog {
+		opts = append(opts, crawling.WithBlogOutputDir(f.outDir))
+	} else {
+		opts = append(opts, crawling.WithOutputDir(f.outDir))
+	}
+	if f.format != "" {
+		format, err := output.ParseFormat(f.format)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, crawling.WithOutputFormat(format))
+	}
+	return opts, nil
+}
+
+// 필수 플래그 확인 (이름, 값 순서의 쌍)
+func require(fs *flag.FlagSet, pairs ...string) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] == "" {
+			return fmt.Errorf("-%s 플래그가 필요합니다 ('navercrawl %s -h' 참고)", pairs[i], fs.Name())
+		}
+	}
+	return nil
+}
+
+// 쉼표로 구분한 목록 (빈 항목 제외)
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func envString(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func envBool(key string) bool {
+	v, _ := strconv.ParseBool(os.Getenv(key))
+	return v
+}
+
+func envInt(key string, def int) int {
+	if v, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+func envFloat(key string, def float64) float64 {
+	if v, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil {
+		return v
+	}
+	return def
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,150 @@
+// navercrawl 은 네이버 카페/블로그 크롤러의 단일 명령행 도구입니다.
+//
+// 사용법:
+//
+//	navercrawl cafe board  -cafe <카페ID> -board <게시판ID> [플래그]
+//	navercrawl cafe search -cafe <카페ID> -query <검색어> [플래그]
+//	navercrawl cafe menus  -cafe <카페ID> [-json]
+//	navercrawl cafe all    -cafe <카페ID> [-include <목록>] [-exclude <목록>] [플래그]
+//	navercrawl cafe article [-cafe <카페ID>] <게시글 ID 또는 주소> [플래그]
+//	navercrawl cafe resolve <카페/게시판/게시글 주소>...
+//	navercrawl blog posts  -blog <블로그ID> [플래그]
+//	navercrawl blog post   -blog <블로그ID> -post <글번호> [플래그]
+//	navercrawl run         -config <설정 파일> [-loop]
+//	navercrawl auth add    -name <이름> (-cookie-file <파일> | -cookie <쿠키>) [-cafes <목록>]
+//	navercrawl auth list | remove <이름>... | rotate [-new-key-file <파일>]
+//
+// 플래그를 생략하면 환경 변수(.env 포함) 값을 기본값으로 사용합니다.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"naverCafeCrawler/internal/crawling"
+
+	"github.com/joho/godotenv"
+)
+
+const usage = `사용법: navercrawl <명령> <하위 명령> [플래그]
+
+명령:
+  cafe board    카페 게시판 크롤링
+  cafe search   카페 검색 결과 크롤링
+  cafe menus    카페 메뉴(게시판) 목록 출력
+  cafe all      카페 전체 게시판 크롤링
+  cafe article  카페 게시글 하나 가져오기 (ID 또는 주소)
+  cafe resolve  카페/게시판/게시글 주소를 카페 ID, 게시판 ID, 게시글 ID 로 변환
+  blog posts    블로그 게시글 목록 크롤링
+  blog post     블로그 게시글 하나 가져오기
+  run           설정 파일(YAML)의 작업 실행
+  auth add      로그인 쿠키를 암호화된 세션 저장소에 이름을 붙여 저장
+  auth list     저장한 세션 목록 출력
+  auth remove   저장한 세션 삭제
+  auth rotate   세션 저장소를 새 암호나 키 파일로 다시 암호화
+
+각 명령의 플래그는 'navercrawl <명령> <하위 명령> -h' 로 확인하세요.
+-cafe 에는 카페 ID 대신 카페 별칭이나 카페/게시판 주소를 넣을 수 있습니다.
+플래그를 생략하면 환경 변수(.env 포함) 값을 기본값으로 사용합니다.
+`
+
+// 하위 명령 실행 함수
+type command func(ctx context.Context, args []string) error
+
+// 하위 명령이 없는 명령은 빈 문자열 키에 등록합니다.
+var commands = map[string]map[string]command{
+	"run": {
+		"": runJobs,
+	},
+	"cafe": {
+		"board":   runCafeBoard,
+		"search":  runCafeSearch,
+		"menus":   runCafeMenus,
+		"all":     runCafeAll,
+		"article": runCafeArticle,
+		"resolve": runCafeResolve,
+	},
+	"blog": {
+		"posts": runBlogPosts,
+		"post":  runBlogPost,
+	},
+	"auth": {
+		"add":    runAuthAdd,
+		"list":   runAuthList,
+		"remove": runAuthRemove,
+		"rotate": runAuthRotate,
+	},
+}
+
+// args 에서 실행할 명령과 나머지 인자를 찾습니다.
+func lookup(args []string) (command, []string, error) {
+	if len(args) == 0 {
+		return nil, nil, fmt.Errorf("명령이 필요합니다")
+	}
+	group, ok := commands[args[0]]
+	if !ok {
+		return nil, nil, fmt.Errorf("알 수 없는 명령: %s", args[0])
+	}
+	if run, ok := group[""]; ok {
+		return run, args[1:], nil
+	}
+	if len(args) < 2 {
+		return nil, nil, fmt.Errorf("하위 명령이 필요합니다: %s", args[0])
+	}
+	run, ok := group[args[1]]
+	if !ok {
+		return nil, nil, fmt.Errorf("알 수 없는 하위 명령: %s %s", args[0], args[1])
+	}
+	return run, args[2:], nil
+}
+
+func init() {
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds)
+}
+
+func main() {
+	err := godotenv.Load()
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Println("Error loading .env file:", err)
+		os.Exit(1)
+	}
+
+	run, args, err := lookup(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n\n%s", err, usage)
+		os.Exit(2)
+	}
+
+	// Ctrl-C / SIGTERM 수신 시 수집한 결과까지 저장하고 종료
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	err = run(ctx, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if errors.Is(err, context.Canceled) {
+		log.Printf("🛑 사용자 요청으로 크롤링을 중단했습니다.")
+		return
+	}
+	if err != nil {
+		stop()
+		switch {
+		case errors.Is(err, crawling.ErrSessionExpired):
+			log.Printf("🔑 로그인 쿠키가 만료되었습니다. 브라우저에서 쿠키를 새로 내보내 'navercrawl auth add -replace' 로 저장하거나 -cookie-file 로 지정하세요.")
+		case errors.Is(err, crawling.ErrRateLimited), errors.Is(err, crawling.ErrBlockedPage):
+			log.Printf("⏳ 네이버가 요청을 제한했습니다. 잠시 후 -resume 으로 이어서 크롤링하거나 초당 요청 수(-rate)를 낮추세요.")
+		case errors.Is(err, crawling.ErrCaptcha):
+			log.Printf("🤖 네이버가 자동 입력 방지(캡차)를 요구합니다. 브라우저에서 캡차를 풀고 쿠키를 새로 내보낸 뒤 -resume 으로 이어서 크롤링하세요.")
+		case errors.Is(err, crawling.ErrMaintenance):
+			log.Printf("🚧 네이버 서비스 점검 중입니다. 점검이 끝난 뒤 -resume 으로 이어서 크롤링하세요.")
+		}
+		log.Fatalf("❌ %v", err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"naverCafeCrawler/internal/config"
+	"naverCafeCrawler/internal/runner"
+)
+
+func runJobs(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	configFile := fs.String("config", envString("NAVER_CONFIG", "navercrawl.yaml"), "작업 설정 파일 (NAVER_CONFIG)")
+	loop := fs.Bool("loop", false, "schedule.every 가 지정된 작업을 종료할 때까지 반복 실행")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		return err
+	}
+	r := runner.New(cfg)
+
+	if *loop {
+		r.Loop(ctx, func(result runner.Result) {
+			runner.PrintSummary(os.Stdout, []runner.Result{result})
+		})
+		return ctx.Err()
+	}
+
+	results := r.RunOnce(ctx)
+	fmt.Println()
+	runner.PrintSummary(os.Stdout, results)
+
+	failed := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failed++
+		}
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d/%d개 작업 실패", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Package cafeurl 은 네이버 카페 주소에서 카페, 게시판(메뉴), 게시글 ID 를 읽습니다.
+//
+// 지원하는 형식:
+//
+//	https://cafe.naver.com/{별칭}
+//	https://cafe.naver.com/{별칭}/{게시글ID}
+//	https://cafe.naver.com/{별칭}?iframe_url=/ArticleRead.nhn%3Fclubid=...
+//	https://cafe.naver.com/ArticleList.nhn?search.clubid={카페ID}&search.menuid={메뉴ID}
+//	https://cafe.naver.com/ArticleRead.nhn?clubid={카페ID}&articleid={게시글ID}
+//	https://cafe.naver.com/ca-fe/cafes/{카페ID}/articles/{게시글ID}
+//	https://cafe.naver.com/ca-fe/cafes/{카페ID}/menus/{메뉴ID}
+//	https://cafe.naver.com/f-e/cafes/{카페ID}/menus/{메뉴ID}
+//	https://m.cafe.naver.com/ca-fe/web/cafes/{카페ID 또는 별칭}/articles/{게시글ID}
+//	https://m.cafe.naver.com/{별칭}/{게시글ID}
+//
+// 숫자만 있는 문자열은 카페 ID, 그 밖의 단어는 별칭으로 봅니다.
+package cafeurl
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Ref 는 주소에서 읽은 카페, 게시판, 게시글 식별자입니다.
+// 주소에 없는 값은 빈 문자열입니다.
+type Ref struct {
+	CafeID    string // 숫자 카페 ID (clubid)
+	Alias     string // 카페 주소의 별칭 (cafe.naver.com/{별칭})
+	MenuID    string
+	ArticleID string
+}
+
+// NeedsLookup 은 카페 ID 를 알려면 별칭을 카페 정보 API 로 조회해야 하는지 반환합니다.
+func (r Ref) NeedsLookup() bool {
+	return r.CafeID == "" && r.Alias != ""
+}
+
+var (
+	numericRe = regexp.MustCompile(`^[0-9]+$`)
+	aliasRe   = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// IsNumeric 은 s 가 숫자로만 이루어졌는지 반환합니다.
+func IsNumeric(s string) bool {
+	return numericRe.MatchString(s)
+}
+
+// 별칭이 아닌 cafe.naver.com 의 첫 경로 (구 주소의 페이지 이름 등)
+var reservedPaths = map[string]bool{
+	"ca-fe": true, "f-e": true, "cafes": true,
+	"ArticleList.nhn": true, "ArticleRead.nhn": true, "MyCafeIntro.nhn": true,
+	"CafeMemberNetworkView.nhn": true, "ArticleSearchList.nhn": true,
+}
+
+// Parse 는 카페/게시판/게시글 주소나 카페 ID, 별칭을 읽습니다.
+// 별칭만 있는 주소는 Ref.Alias 만 채워지며, 카페 ID 는 카페 정보 API 로 조회해야 합니다.
+func Parse(raw string) (Ref, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return Ref{}, fmt.Errorf("카페 주소가 비어 있습니다")
+	}
+	if IsNumeric(raw) {
+		return Ref{CafeID: raw}, nil
+	}
+	if aliasRe.MatchString(raw) {
+		return Ref{Alias: raw}, nil
+	}
+
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Ref{}, fmt.Errorf("잘못된 카페 주소 %q: %v", raw, err)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host != "cafe.naver.com" && host != "m.cafe.naver.com" {
+		return Ref{}, fmt.Errorf("네이버 카페 주소가 아닙니다: %s", raw)
+	}
+
+	var ref Ref
+	parseQuery(&ref, u.Query())
+
+	// 구 주소는 실제 페이지 주소를 iframe_url 파라미터에 담음
+	for _, key := range []string{"iframe_url_utf8", "iframe_url"} {
+		if inner := u.Query().Get(key); inner != "" {
+			// 한 번 더 인코딩된 경우 (%253F → %3F → ?)
+			if !strings.Contains(inner, "?") {
+				if unescaped, err := url.QueryUnescape(inner); err == nil {
+					inner = unescaped
+				}
+			}
+			if innerURL, err := url.Parse(inner); err == nil {
+				parseQuery(&ref, innerURL.Query())
+				parsePath(&ref, innerURL.Path)
+			}
+		}
+	}
+	parsePath(&ref, u.EscapedPath())
+
+	if ref.CafeID == "" && ref.Alias == "" {
+		return Ref{}, fmt.Errorf("주소에서 카페를 찾을 수 없습니다: %s", raw)
+	}
+	return ref, nil
+}
+
+// 쿼리 파라미터 (clubid, search.clubid, menuid, search.menuid, articleid)
+func parseQuery(ref *Ref, q url.Values) {
+	set := func(dst *string, keys ...string) {
+		for _, key := range keys {
+			if v := q.Get(key); *dst == "" && IsNumeric(v) {
+				*dst = v
+			}
+		}
+	}
+	set(&ref.CafeID, "clubid", "search.clubid", "clubId", "cafeId")
+	set(&ref.MenuID, "menuid", "search.menuid", "menuId")
+	set(&ref.ArticleID, "articleid", "articleId")
+}
+
+// 경로 (/ca-fe/cafes/{id}/menus/{id}, /{별칭}/{게시글ID} 등)
+func parsePath(ref *Ref, p string) {
+	var segs []string
+	for _, s := range strings.Split(p, "/") {
+		if s != "" {
+			if unescaped, err := url.PathUnescape(s); err == nil {
+				s = unescaped
+			}
+			segs = append(segs, s)
+		}
+	}
+	if len(segs) == 0 {
+		return
+	}
+
+	if segs[0] == "ca-fe" || segs[0] == "f-e" {
+		// /ca-fe/[web/]cafes/{카페}/[menus/{메뉴}]/[articles/{게시글}]
+		for i := 1; i+1 < len(segs); i++ {
+			v := segs[i+1]
+			switch segs[i] {
+			case "cafes":
+				setCafe(ref, v)
+			case "menus":
+				if IsNumeric(v) && ref.MenuID == "" {
+					ref.MenuID = v
+				}
+			case "articles":
+				if IsNumeric(v) && ref.ArticleID == "" {
+					ref.ArticleID = v
+				}
+			}
+		}
+		return
+	}
+
+	if reservedPaths[segs[0]] || strings.HasSuffix(segs[0], ".nhn") {
+		return
+	}
+	// /{별칭}[/{게시글ID}]
+	setCafe(ref, segs[0])
+	if len(segs) > 1 && IsNumeric(segs[1]) && ref.ArticleID == "" {
+		ref.ArticleID = segs[1]
+	}
+}
+
+func setCafe(ref *Ref, v string) {
+	switch {
+	case IsNumeric(v):
+		if ref.CafeID == "" {
+			ref.CafeID = v
+		}
+	case aliasRe.MatchString(v):
+		if ref.Alias == "" {
+			ref.Alias = v
+		}
+	}
+}
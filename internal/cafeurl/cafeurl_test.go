@@ -0,0 +1,84 @@
+package cafeurl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    Ref
+		wantErr string
+	}{
+		{"카페 ID", " 27842958 ", Ref{CafeID: "27842958"}, ""},
+		{"별칭", "steamindiegame", Ref{Alias: "steamindiegame"}, ""},
+		{"별칭 주소", "https://cafe.naver.com/steamindiegame", Ref{Alias: "steamindiegame"}, ""},
+		{"스킴 없는 게시글 주소", "cafe.naver.com/steamindiegame/123", Ref{Alias: "steamindiegame", ArticleID: "123"}, ""},
+		{"호스트 대소문자", "HTTPS://Cafe.Naver.com/steamindiegame", Ref{Alias: "steamindiegame"}, ""},
+		{"구 게시판 주소",
+			"https://cafe.naver.com/ArticleList.nhn?search.clubid=27842958&search.menuid=3&search.boardtype=L",
+			Ref{CafeID: "27842958", MenuID: "3"}, ""},
+		{"구 게시글 주소",
+			"https://cafe.naver.com/ArticleRead.nhn?clubid=27842958&articleid=123",
+			Ref{CafeID: "27842958", ArticleID: "123"}, ""},
+		{"iframe_url",
+			"https://cafe.naver.com/steamindiegame?iframe_url=/ArticleRead.nhn%3Fclubid=27842958%26articleid=123",
+			Ref{CafeID: "27842958", Alias: "steamindiegame", ArticleID: "123"}, ""},
+		{"두 번 인코딩한 iframe_url_utf8",
+			"https://cafe.naver.com/steamindiegame?iframe_url_utf8=%2FArticleList.nhn%253Fsearch.clubid%253D27842958%2526search.menuid%253D3",
+			Ref{CafeID: "27842958", Alias: "steamindiegame", MenuID: "3"}, ""},
+		{"새 게시글 주소",
+			"https://cafe.naver.com/ca-fe/cafes/27842958/articles/123?boardtype=L",
+			Ref{CafeID: "27842958", ArticleID: "123"}, ""},
+		{"새 게시판 주소",
+			"https://cafe.naver.com/f-e/cafes/27842958/menus/3",
+			Ref{CafeID: "27842958", MenuID: "3"}, ""},
+		{"모바일 별칭 게시글 주소",
+			"https://m.cafe.naver.com/ca-fe/web/cafes/steamindiegame/articles/123",
+			Ref{Alias: "steamindiegame", ArticleID: "123"}, ""},
+		{"모바일 짧은 주소", "https://m.cafe.naver.com/steamindiegame/123", Ref{Alias: "steamindiegame", ArticleID: "123"}, ""},
+		{"숫자가 아닌 게시판 ID 무시",
+			"https://cafe.naver.com/ca-fe/cafes/27842958/menus/all",
+			Ref{CafeID: "27842958"}, ""},
+		{"빈 문자열", "  ", Ref{}, "비어 있습니다"},
+		{"다른 호스트", "https://blog.naver.com/steamindiegame", Ref{}, "네이버 카페 주소가 아닙니다"},
+		{"카페 없는 주소", "https://cafe.naver.com/ArticleList.nhn?search.menuid=3", Ref{}, "카페를 찾을 수 없습니다"},
+		{"호스트만", "cafe.naver.com", Ref{}, "카페를 찾을 수 없습니다"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse(%q) = %+v, %v, want %q 포함 오류", tt.raw, got, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsLookup(t *testing.T) {
+	tests := []struct {
+		ref  Ref
+		want bool
+	}{
+		{Ref{Alias: "steamindiegame"}, true},
+		{Ref{CafeID: "27842958", Alias: "steamindiegame"}, false},
+		{Ref{CafeID: "27842958"}, false},
+		{Ref{}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.ref.NeedsLookup(); got != tt.want {
+			t.Errorf("%+v.NeedsLookup() = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+// Package config 는 여러 크롤링 작업을 선언하는 YAML 설정 파일을 읽습니다.
+//
+// 예시:
+//
+//	rate_limit:
+//	  rps: 0.5
+//	  burst: 1
+//	  per_host: 0.3
+//	retry:
+//	  retries: 3
+//	  base_delay: 2s
+//	  max_delay: 1m
+//	parallel: 2
+//	store:
+//	  key_file: ./navercrawl.key
+//	cookies:
+//	  main:
+//	    store: main
+//	  sub:
+//	    cookies_file: ./cookies/sub.txt
+//	defaults:
+//	  output:
+//	    dir: output
+//	    format: jsonl
+//	jobs:
+//	  - name: notice
+//	    type: cafe-board
+//	    cafe_id: "12345"
+//	    board_id: "6"
+//	    cookie: main
+//	    incremental: true
+//	    schedule:
+//	      every: 1h
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"naverCafeCrawler/internal/cafeurl"
+	"naverCafeCrawler/internal/credstore"
+	"naverCafeCrawler/internal/output"
+	"naverCafeCrawler/internal/session"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 작업 종류
+const (
+	JobCafeBoard  = "cafe-board"
+	JobCafeSearch = "cafe-search"
+	JobCafe       = "cafe" // 카페 전체 게시판
+	JobBlog       = "blog"
+)
+
+// Config 는 설정 파일 전체입니다.
+type Config struct {
+	RateLimit RateLimit               `yaml:"rate_limit"`
+	Retry     Retry                   `yaml:"retry"`
+	Parallel  int                     `yaml:"parallel"` // 동시에 실행할 작업 수 (기본값 1)
+	Store     Store                   `yaml:"store"`
+	Cookies   map[string]CookieSource `yaml:"cookies"`
+	Defaults  JobDefaults             `yaml:"defaults"`
+	Jobs      []Job                   `yaml:"jobs"`
+}
+
+// RateLimit 은 모든 작업과 세션이 공유하는 요청 속도 제한입니다.
+type RateLimit struct {
+	RPS     float64 `yaml:"rps"`      // 초당 최대 요청 수 (0 이하는 무제한)
+	Burst   int     `yaml:"burst"`    // 연속으로 허용할 최대 요청 수
+	PerHost float64 `yaml:"per_host"` // 호스트마다 초당 최대 요청 수 (0 이하는 호스트별로 제한하지 않음)
+}
+
+// Retry 는 모든 작업에 적용하는 요청 재시도 정책입니다. 생략한 값은 기본 정책(crawling.DefaultRetryPolicy)을 따릅니다.
+type Retry struct {
+	Retries   *int     `yaml:"retries"`    // 일시적으로 실패한 요청의 재시도 횟수 (0이면 재시도 없음)
+	BaseDelay Duration `yaml:"base_delay"` // 첫 재시도 전 대기 시간, 이후 두 배씩 증가
+	MaxDelay  Duration `yaml:"max_delay"`  // 재시도 대기 시간 상한
+}
+
+// Store 는 cookies 의 store 항목이 세션을 읽는 암호화된 세션 저장소('navercrawl auth')입니다.
+// 키 파일을 지정하지 않으면 NAVER_STORE_PASSPHRASE 환경 변수의 암호로 엽니다.
+type Store struct {
+	Path    string `yaml:"path"`     // 저장소 파일 (비우면 기본 경로)
+	KeyFile string `yaml:"key_file"` // 저장소 키 파일
+}
+
+// Open 은 세션 저장소를 엽니다.
+func (s Store) Open() (*credstore.Store, error) {
+	path := s.Path
+	if path == "" {
+		path = credstore.DefaultPath()
+	}
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("세션 저장소 열기 실패: %v", err)
+	}
+	secret, err := credstore.Secret(s.KeyFile)
+	if errors.Is(err, credstore.ErrNoSecret) {
+		return nil, fmt.Errorf("%w: store.key_file 이나 %s 환경 변수를 지정하세요", err, credstore.PassphraseEnv)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return credstore.Open(path, secret)
+}
+
+// CookieSource 는 쿠키 값을 어디서 읽을지 지정합니다. 다섯 중 하나만 사용합니다.
+type CookieSource struct {
+	Store       string `yaml:"store"`        // 세션 저장소에 저장한 세션 이름
+	Env         string `yaml:"env"`          // 환경 변수 이름
+	File        string `yaml:"file"`         // 쿠키 문자열이 담긴 파일 경로
+	Value       string `yaml:"value"`        // 쿠키 문자열 (설정 파일에 직접 기록)
+	CookiesFile string `yaml:"cookies_file"` // cookies.txt 또는 브라우저 JSON 쿠키 파일 경로
+
+	// 세션 풀(작업의 cookies)에서 이 세션으로 접근할 수 있는 카페 ID (비우면 모든 카페)
+	Cafes []string `yaml:"cafes"`
+	// 세션 풀에서 이 세션 전용 요청 속도 제한 (비우면 전체 rate_limit 만 적용)
+	// 전체 rate_limit 은 모든 세션을 합친 상한으로 함께 적용됩니다.
+	RateLimit *RateLimit `yaml:"rate_limit"`
+}
+
+// Session 은 쿠키를 읽어 로그인 세션을 만듭니다.
+// store 는 store 세션 저장소에서 읽고, cookies_file 은 파일의 만료 시각과 도메인을 그대로 사용하며,
+// 나머지는 쿠키 문자열로 읽습니다.
+func (s CookieSource) Session(store *credstore.Store) (*session.Session, error) {
+	if s.Store != "" {
+		if store == nil {
+			return nil, fmt.Errorf("세션 저장소가 열려 있지 않습니다")
+		}
+		return store.Session(s.Store)
+	}
+	if s.CookiesFile != "" {
+		return session.Load(s.CookiesFile)
+	}
+	cookie, err := s.header()
+	if err != nil {
+		return nil, err
+	}
+	return session.FromHeader(cookie)
+}
+
+// 쿠키를 읽을 곳이 둘 이상 지정되었는지 확인
+func (s CookieSource) validate() error {
+	var set []string
+	for _, src := range []struct{ key, value string }{
+		{"store", s.Store}, {"env", s.Env}, {"file", s.File}, {"value", s.Value}, {"cookies_file", s.CookiesFile},
+	} {
+		if src.value != "" {
+			set = append(set, src.key)
+		}
+	}
+	if len(set) > 1 {
+		return fmt.Errorf("%s 중 하나만 지정해야 합니다", strings.Join(set, ", "))
+	}
+	return nil
+}
+
+// env, file, value 의 쿠키 문자열
+func (s CookieSource) header() (string, error) {
+	switch {
+	case s.Env != "":
+		v := os.Getenv(s.Env)
+		if v == "" {
+			return "", fmt.Errorf("환경 변수 %s 가 설정되지 않았습니다", s.Env)
+		}
+		return v, nil
+	case s.File != "":
+		data, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("쿠키 파일 읽기 실패: %v", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	default:
+		return s.Value, nil
+	}
+}
+
+// Output 은 작업 결과 저장 위치와 형식입니다.
+type Output struct {
+	Dir    string `yaml:"dir"`
+	Format string `yaml:"format"` // json, jsonl, csv, sqlite
+	// 이미지/동영상/첨부 파일을 내려받을 디렉토리 (비우면 내려받지 않음)
+	MediaDir string `yaml:"media_dir"`
+}
+
+// Schedule 은 작업 반복 주기입니다. Every 가 0 이면 한 번만 실행합니다.
+type Schedule struct {
+	Every Duration `yaml:"every"`
+}
+
+// Duration 은 "30m", "6h" 같은 문자열로 읽는 time.Duration 입니다.
+type Duration time.Duration
+
+// UnmarshalYAML 은 time.ParseDuration 형식의 문자열을 읽습니다.
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var s string
+	if err := node.Decode(&s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("잘못된 시간 간격 %q: %v", s, err)
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// JobDefaults 는 작업에서 생략한 값에 적용되는 기본값입니다.
+type JobDefaults struct {
+	Output      Output   `yaml:"output"`
+	Cookie      string   `yaml:"cookie"`
+	Cookies     []string `yaml:"cookies"`
+	MaxPages    int      `yaml:"max_pages"`
+	PageSize    int      `yaml:"page_size"`
+	Concurrency int      `yaml:"concurrency"`
+}
+
+// Job 은 크롤링 작업 하나입니다.
+type Job struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // cafe-board, cafe-search, cafe, blog
+
+	CafeID  string `yaml:"cafe_id"`  // 카페 ID, 별칭 또는 카페/게시판 주소
+	BoardID string `yaml:"board_id"` // cafe_id 가 게시판 주소이면 생략 가능
+	Query   string `yaml:"query"`
+	BlogID  string `yaml:"blog_id"`
+
+	// cafe 작업에서 크롤링할/제외할 게시판 (메뉴 ID 또는 이름, 폴더 포함)
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+
+	Cookie      string   `yaml:"cookie"`  // cookies 항목의 이름
+	Cookies     []string `yaml:"cookies"` // 세션 풀로 쓸 cookies 항목의 이름들 (cookie 대신 지정)
+	MaxPages    int      `yaml:"max_pages"`
+	PageSize    int      `yaml:"page_size"`
+	Concurrency int      `yaml:"concurrency"`
+	Resume      bool     `yaml:"resume"`
+	Incremental bool     `yaml:"incremental"`
+	StreamOnly  bool     `yaml:"stream_only"`
+	Output      Output   `yaml:"output"`
+	Schedule    Schedule `yaml:"schedule"`
+}
+
+// Load 는 filename 설정 파일을 읽고 기본값을 적용한 뒤 검증합니다.
+func Load(filename string) (*Config, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("설정 파일 읽기 실패: %v", err)
+	}
+	return Parse(data)
+}
+
+// Parse 는 YAML 설정을 읽고 기본값을 적용한 뒤 검증합니다.
+func Parse(data []byte) (*Config, error) {
+	cfg := &Config{RateLimit: RateLimit{RPS: 0.5, Burst: 1}, Parallel: 1}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("설정 파일 파싱 실패: %v", err)
+	}
+	cfg.applyDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Config) applyDefaults() {
+	d := c.Defaults
+	for i := range c.Jobs {
+		job := &c.Jobs[i]
+		if job.Name == "" {
+			job.Name = fmt.Sprintf("job-%d", i+1)
+		}
+		if job.Output.Dir == "" {
+			job.Output.Dir = d.Output.Dir
+		}
+		if job.Output.Format == "" {
+			job.Output.Format = d.Output.Format
+		}
+		if job.Output.MediaDir == "" {
+			job.Output.MediaDir = d.Output.MediaDir
+		}
+		if job.Cookie == "" && len(job.Cookies) == 0 {
+			job.Cookie = d.Cookie
+			job.Cookies = d.Cookies
+		}
+		if job.MaxPages == 0 {
+			job.MaxPages = d.MaxPages
+		}
+		if job.PageSize == 0 {
+			job.PageSize = d.PageSize
+		}
+		if job.Concurrency == 0 {
+			job.Concurrency = d.Concurrency
+		}
+	}
+}
+
+func (c *Config) validate() error {
+	if c.Parallel < 1 {
+		return fmt.Errorf("parallel 은 1 이상이어야 합니다")
+	}
+	if c.Retry.Retries != nil && *c.Retry.Retries < 0 {
+		return fmt.Errorf("retry.retries 는 0 이상이어야 합니다")
+	}
+	if c.Retry.BaseDelay < 0 || c.Retry.MaxDelay < 0 {
+		return fmt.Errorf("retry.base_delay, retry.max_delay 는 0 이상이어야 합니다")
+	}
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("설정 파일에 작업(jobs)이 없습니다")
+	}
+	for name, src := range c.Cookies {
+		if err := src.validate(); err != nil {
+			return fmt.Errorf("쿠키 %s: %v", name, err)
+		}
+		for _, id := range src.Cafes {
+			if !cafeurl.IsNumeric(id) {
+				return fmt.Errorf("쿠키 %s: cafes 에는 숫자 카페 ID 를 적어야 합니다: %q", name, id)
+			}
+		}
+	}
+	names := make(map[string]bool)
+	for _, job := range c.Jobs {
+		if names[job.Name] {
+			return fmt.Errorf("작업 이름이 중복되었습니다: %s", job.Name)
+		}
+		names[job.Name] = true
+		if err := c.validateJob(job); err != nil {
+			return fmt.Errorf("작업 %s: %v", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) validateJob(job Job) error {
+	switch job.Type {
+	case JobCafeBoard:
+		if job.CafeID == "" {
+			return fmt.Errorf("cafe_id 와 board_id 가 필요합니다")
+		}
+		if job.BoardID == "" {
+			if ref, err := cafeurl.Parse(job.CafeID); err != nil || ref.MenuID == "" {
+				return fmt.Errorf("cafe_id 와 board_id 가 필요합니다")
+			}
+		}
+	case JobCafeSearch:
+		if job.CafeID == "" || job.Query == "" {
+			return fmt.Errorf("cafe_id 와 query 가 필요합니다")
+		}
+	case JobCafe:
+		if job.CafeID == "" {
+			return fmt.Errorf("cafe_id 가 필요합니다")
+		}
+	case JobBlog:
+		if job.BlogID == "" {
+			return fmt.Errorf("blog_id 가 필요합니다")
+		}
+	default:
+		return fmt.Errorf("알 수 없는 작업 종류: %q (%s, %s, %s, %s 중 하나)", job.Type, JobCafeBoard, JobCafeSearch, JobCafe, JobBlog)
+	}
+	if job.Type != JobBlog {
+		if _, err := cafeurl.Parse(job.CafeID); err != nil {
+			return err
+		}
+	}
+	if job.Cookie != "" && len(job.Cookies) > 0 {
+		return fmt.Errorf("cookie 와 cookies 는 함께 지정할 수 없습니다")
+	}
+	for _, name := range append([]string{job.Cookie}, job.Cookies...) {
+		if name == "" {
+			continue
+		}
+		if _, ok := c.Cookies[name]; !ok {
+			return fmt.Errorf("정의되지 않은 쿠키: %s", name)
+		}
+	}
+	if job.Output.Format != "" {
+		if _, err := output.ParseFormat(job.Output.Format); err != nil {
+			return err
+		}
+	}
+	return nil
+}
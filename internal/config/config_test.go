@@ -0,0 +1,81 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testJobs = `
+jobs:
+  - type: blog
+    blog_id: myblog
+`
+
+func TestParseRetry(t *testing.T) {
+	three := 3
+	zero := 0
+	tests := []struct {
+		name    string
+		yaml    string
+		want    Retry
+		wantErr string
+	}{
+		{"생략", "", Retry{}, ""},
+		{"전체", "retry:\n  retries: 3\n  base_delay: 500ms\n  max_delay: 30s\n",
+			Retry{Retries: &three, BaseDelay: Duration(500 * time.Millisecond), MaxDelay: Duration(30 * time.Second)}, ""},
+		{"재시도 없음", "retry:\n  retries: 0\n", Retry{Retries: &zero}, ""},
+		{"음수 재시도", "retry:\n  retries: -1\n", Retry{}, "retry.retries"},
+		{"음수 대기", "retry:\n  base_delay: -1s\n", Retry{}, "retry.base_delay"},
+		{"잘못된 대기", "retry:\n  max_delay: soon\n", Retry{}, "soon"},
+		{"알 수 없는 키", "retry:\n  attempts: 3\n", Retry{}, "attempts"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := Parse([]byte(tt.yaml + testJobs))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Parse() error = %v, want %q 포함", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := cfg.Retry
+			if (got.Retries == nil) != (tt.want.Retries == nil) ||
+				(got.Retries != nil && *got.Retries != *tt.want.Retries) ||
+				got.BaseDelay != tt.want.BaseDelay || got.MaxDelay != tt.want.MaxDelay {
+				t.Errorf("Retry = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCookies(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"store", "cookies:\n  main:\n    store: main\n", ""},
+		{"cookies_file 과 cafes", "cookies:\n  main:\n    cookies_file: cookies.txt\n    cafes: [\"10050146\"]\n", ""},
+		{"두 곳", "cookies:\n  main:\n    env: NAVER_COOKIE\n    file: cookie.txt\n", "env, file 중 하나만"},
+		{"세 곳", "cookies:\n  main:\n    store: main\n    value: NID_AUT=x\n    cookies_file: cookies.txt\n", "store, value, cookies_file 중 하나만"},
+		{"숫자가 아닌 카페", "cookies:\n  main:\n    store: main\n    cafes: [steamindiegame]\n", "숫자 카페 ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.yaml + testJobs))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want %q 포함", err, tt.wantErr)
+			}
+		})
+	}
+}
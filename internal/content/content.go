@@ -0,0 +1,331 @@
+// Package content 는 게시글 본문 HTML(SmartEditor 마크업 포함)을
+// 읽기 쉬운 일반 텍스트와 Markdown 으로 변환합니다.
+package content
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Converted 는 같은 본문의 세 가지 표현입니다.
+type Converted struct {
+	HTML     string
+	Text     string
+	Markdown string
+}
+
+// Convert 는 본문 HTML 을 텍스트와 Markdown 으로 변환합니다.
+func Convert(htmlContent string) Converted {
+	return Converted{
+		HTML:     htmlContent,
+		Text:     ToText(htmlContent),
+		Markdown: ToMarkdown(htmlContent),
+	}
+}
+
+// ToText 는 HTML 을 태그 없는 일반 텍스트로 변환합니다.
+// 문단과 줄바꿈은 유지하고 이미지는 생략합니다.
+func ToText(htmlContent string) string {
+	return render(htmlContent, false)
+}
+
+// ToMarkdown 은 HTML 을 Markdown 으로 변환합니다.
+// 제목, 목록, 인용, 링크, 이미지, 강조, 코드 블록, 표를 유지합니다.
+func ToMarkdown(htmlContent string) string {
+	return render(htmlContent, true)
+}
+
+func render(htmlContent string, markdown bool) string {
+	if strings.TrimSpace(htmlContent) == "" {
+		return ""
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return ""
+	}
+	r := renderer{markdown: markdown}
+	var b strings.Builder
+	for _, n := range doc.Find("body").Nodes {
+		b.WriteString(r.children(n))
+	}
+	return normalize(b.String())
+}
+
+type renderer struct {
+	markdown bool
+}
+
+func (r renderer) children(n *html.Node) string {
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(r.node(c))
+	}
+	return b.String()
+}
+
+func (r renderer) node(n *html.Node) string {
+	switch n.Type {
+	case html.TextNode:
+		return collapseSpace(n.Data)
+	case html.ElementNode:
+	default:
+		return ""
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Head, atom.Template, atom.Button:
+		return ""
+	case atom.Br:
+		return "\n"
+	case atom.Hr:
+		if r.markdown {
+			return "\n\n---\n\n"
+		}
+		return "\n\n"
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		text := oneLine(r.children(n))
+		if text == "" {
+			return ""
+		}
+		if r.markdown {
+			level := int(n.Data[1] - '0')
+			text = strings.Repeat("#", level) + " " + text
+		}
+		return block(text)
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer,
+		atom.Main, atom.Figure, atom.Figcaption, atom.Dl, atom.Dt, atom.Dd:
+		return block(r.children(n))
+	case atom.Ul, atom.Ol:
+		return block(r.list(n))
+	case atom.Blockquote:
+		inner := strings.TrimSpace(normalize(r.children(n)))
+		if r.markdown {
+			inner = prefixLines(inner, "> ", ">")
+		}
+		return block(inner)
+	case atom.Pre:
+		code := strings.Trim(textContent(n), "\n")
+		if r.markdown {
+			code = "```\n" + code + "\n```"
+		}
+		return "\n\n" + code + "\n\n"
+	case atom.Table:
+		return block(r.table(n))
+	case atom.A:
+		return r.link(n)
+	case atom.Img:
+		return r.image(n)
+	case atom.Strong, atom.B:
+		return r.emphasis(n, "**")
+	case atom.Em, atom.I:
+		return r.emphasis(n, "*")
+	case atom.Del, atom.S, atom.Strike:
+		return r.emphasis(n, "~~")
+	case atom.Code:
+		if r.markdown {
+			return wrapInline(collapseSpace(textContent(n)), "`")
+		}
+		return collapseSpace(textContent(n))
+	default:
+		return r.children(n)
+	}
+}
+
+func (r renderer) emphasis(n *html.Node, mark string) string {
+	inner := r.children(n)
+	if !r.markdown {
+		return inner
+	}
+	return wrapInline(inner, mark)
+}
+
+func (r renderer) link(n *html.Node) string {
+	text := oneLine(r.children(n))
+	href := strings.TrimSpace(attr(n, "href"))
+	if !r.markdown || href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+		return text
+	}
+	if text == "" {
+		return "<" + href + ">"
+	}
+	return fmt.Sprintf("[%s](%s)", text, href)
+}
+
+func (r renderer) image(n *html.Node) string {
+	if !r.markdown {
+		return ""
+	}
+	src := ImageSource(n)
+	if src == "" {
+		return ""
+	}
+	return fmt.Sprintf("![%s](%s)", oneLine(attr(n, "alt")), src)
+}
+
+func (r renderer) list(n *html.Node) string {
+	ordered := n.DataAtom == atom.Ol
+	var items []string
+	index := 1
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.DataAtom != atom.Li {
+			continue
+		}
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", index)
+			index++
+		}
+		item := strings.TrimSpace(normalize(r.children(c)))
+		item = strings.ReplaceAll(item, "\n\n", "\n")
+		items = append(items, marker+prefixLines(item, strings.Repeat(" ", len(marker)), "")[len(marker):])
+	}
+	return strings.Join(items, "\n")
+}
+
+func (r renderer) table(n *html.Node) string {
+	var rows [][]string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if c.DataAtom == atom.Tr {
+				var cells []string
+				for td := c.FirstChild; td != nil; td = td.NextSibling {
+					if td.Type == html.ElementNode && (td.DataAtom == atom.Td || td.DataAtom == atom.Th) {
+						cells = append(cells, oneLine(r.children(td)))
+					}
+				}
+				rows = append(rows, cells)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+	if len(rows) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, cells := range rows {
+		if !r.markdown {
+			b.WriteString(strings.Join(cells, "\t") + "\n")
+			continue
+		}
+		for j := range cells {
+			cells[j] = strings.ReplaceAll(cells[j], "|", `\|`)
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			b.WriteString("|" + strings.Repeat(" --- |", len(cells)) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// ImageSource 는 img 요소의 실제 이미지 주소를 반환합니다.
+// 지연 로딩 속성(data-lazy-src, data-src)이 있으면 그 값을 우선합니다.
+func ImageSource(n *html.Node) string {
+	for _, key := range []string{"data-lazy-src", "data-src", "src"} {
+		if v := strings.TrimSpace(attr(n, key)); v != "" && !strings.HasPrefix(v, "data:") {
+			return v
+		}
+	}
+	return ""
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func textContent(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		if n.Type == html.ElementNode && n.DataAtom == atom.Br {
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// 블록 요소: 앞뒤에 빈 줄을 두고 내용의 앞뒤 공백 제거
+func block(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	return "\n\n" + s + "\n\n"
+}
+
+// 강조 기호로 감싸되 앞뒤 공백은 기호 바깥에 유지
+func wrapInline(s, mark string) string {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return s
+	}
+	lead := s[:strings.Index(s, trimmed)]
+	trail := s[len(lead)+len(trimmed):]
+	return lead + mark + trimmed + mark + trail
+}
+
+func prefixLines(s, prefix, emptyPrefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = emptyPrefix
+		} else {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	spaceRe     = regexp.MustCompile(`[ \t\r\n\f]+`)
+	blankLineRe = regexp.MustCompile(`\n{3,}`)
+)
+
+// 보이지 않는 문자 제거와 공백 정리
+var invisibleReplacer = strings.NewReplacer("\u200b", "", "\ufeff", "", "\u00a0", " ")
+
+func collapseSpace(s string) string {
+	return spaceRe.ReplaceAllString(invisibleReplacer.Replace(s), " ")
+}
+
+func oneLine(s string) string {
+	return strings.TrimSpace(spaceRe.ReplaceAllString(s, " "))
+}
+
+// 줄 끝 공백을 지우고 빈 줄은 최대 한 줄만 남김
+// 블록 사이 공백 텍스트 노드가 남긴 줄 앞 공백 한 칸도 지웁니다. (목록 들여쓰기는 두 칸 이상)
+func normalize(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if len(line) > 1 && line[0] == ' ' && line[1] != ' ' {
+			line = line[1:]
+		}
+		lines[i] = line
+	}
+	return strings.TrimSpace(blankLineRe.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
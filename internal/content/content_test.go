@@ -0,0 +1,56 @@
+package content
+
+import "testing"
+
+func TestToMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"빈 본문", "  \n", ""},
+		{"문단과 줄바꿈", "<p>첫 줄<br>둘째 줄</p>\n<p>  다음   문단 </p>", "첫 줄\n둘째 줄\n\n다음 문단"},
+		{"제목", "<h2>공지 <b>사항</b></h2><p>내용</p>", "## 공지 **사항**\n\n내용"},
+		{"강조", "<p>아주<strong> 중요 </strong><em>한</em> <s>내용</s></p>", "아주 **중요** *한* ~~내용~~"},
+		{"링크", `<p><a href="https://example.com/a?b=1">예제</a> <a href="#top">위로</a> <a href="https://example.com"></a></p>`,
+			"[예제](https://example.com/a?b=1) 위로 <https://example.com>"},
+		{"지연 로딩 이미지", `<p><img src="data:image/gif;base64,R0lG" data-lazy-src="https://postfiles.pstatic.net/a.jpg" alt="사진 1"></p>`,
+			"![사진 1](https://postfiles.pstatic.net/a.jpg)"},
+		{"목록", "<ul><li>하나</li><li>둘<br>이어서</li></ul><ol><li>첫째</li><li>둘째</li></ol>",
+			"- 하나\n- 둘\n  이어서\n\n1. 첫째\n2. 둘째"},
+		{"인용", "<blockquote><p>인용 1</p><p>인용 2</p></blockquote>", "> 인용 1\n>\n> 인용 2"},
+		{"코드", "<p>실행: <code>go test</code></p><pre>func main() {\n\tfmt.Println()\n}</pre>",
+			"실행: `go test`\n\n```\nfunc main() {\n\tfmt.Println()\n}\n```"},
+		{"표", "<table><tr><th>이름</th><th>값</th></tr><tr><td>a|b</td><td>1</td></tr></table>",
+			"| 이름 | 값 |\n| --- | --- |\n| a\\|b | 1 |"},
+		{"구분선", "<p>위</p><hr><p>아래</p>", "위\n\n---\n\n아래"},
+		{"스크립트와 보이지 않는 문자 제거", "<p>보\u200b이는&nbsp;글<script>alert(1)</script></p><style>p{}</style>", "보이는 글"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToMarkdown(tt.html); got != tt.want {
+				t.Errorf("ToMarkdown(%q)\n got: %q\nwant: %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{"서식 제거", `<h1>제목</h1><p><b>굵게</b> <a href="https://example.com">링크</a><img src="https://example.com/a.jpg"></p>`, "제목\n\n굵게 링크"},
+		{"목록", "<ol><li>첫째</li><li>둘째</li></ol>", "1. 첫째\n2. 둘째"},
+		{"인용", "<blockquote>인용</blockquote>", "인용"},
+		{"표", "<table><tr><td>a</td><td>b</td></tr></table>", "a\tb"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToText(tt.html); got != tt.want {
+				t.Errorf("ToText(%q)\n got: %q\nwant: %q", tt.html, got, tt.want)
+			}
+		})
+	}
+}
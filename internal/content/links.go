@@ -0,0 +1,194 @@
+package content
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// LinkKind 는 본문에서 찾은 외부 링크의 종류입니다.
+type LinkKind string
+
+const (
+	LinkAnchor   LinkKind = "link"      // 일반 <a> 링크 (이미지에 걸린 링크 포함)
+	LinkCard     LinkKind = "link_card" // se-oglink 링크 카드
+	LinkVideo    LinkKind = "video"     // YouTube, 네이버 TV 등 임베드 동영상
+	LinkMapPlace LinkKind = "map"       // 지도 장소
+)
+
+// Link 는 본문의 외부 링크 하나입니다. URL 은 리다이렉트 래퍼를 벗긴 실제 주소입니다.
+type Link struct {
+	URL         string   `json:"url"`
+	Kind        LinkKind `json:"kind"`
+	Domain      string   `json:"domain"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+// ExtractLinks 는 본문 HTML 의 외부 링크를 등장 순서대로 중복 없이 찾습니다.
+func ExtractLinks(htmlContent string) []Link {
+	if strings.TrimSpace(htmlContent) == "" {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+	return LinksFromSelection(doc.Selection)
+}
+
+// LinksFromSelection 은 이미 파싱한 문서(예: 블로그 .se-main-container)에서 외부 링크를 찾습니다.
+// 일반 링크, 링크 카드, iframe 임베드 동영상, 지도 장소를 찾으며
+// 첨부 파일 내려받기 링크와 http(s) 가 아닌 주소는 제외합니다.
+func LinksFromSelection(root *goquery.Selection) []Link {
+	var links []Link
+	index := make(map[string]int)
+	add := func(raw string, kind LinkKind, title, description string) {
+		u := CanonicalURL(raw)
+		if u == "" {
+			return
+		}
+		if i, ok := index[u]; ok {
+			// 같은 주소가 다시 나오면 비어 있는 정보만 채움
+			if links[i].Title == "" {
+				links[i].Title = title
+			}
+			if links[i].Description == "" {
+				links[i].Description = description
+			}
+			return
+		}
+		index[u] = len(links)
+		links = append(links, Link{URL: u, Kind: kind, Domain: domain(u), Title: title, Description: description})
+	}
+
+	// 링크 카드, 동영상, 지도 컴포넌트는 블록 파서로 읽고 그 안의 a/iframe 은 건너뜀
+	const embedded = ".se-oglink, .se-video, .se-oembed, .se-map, .se-placesMap"
+
+	root.Find("a, iframe, " + embedded).Each(func(_ int, s *goquery.Selection) {
+		switch {
+		case s.Is(embedded):
+			if s.Parent().Closest(embedded).Length() > 0 {
+				return
+			}
+			for _, b := range componentBlocks(s) {
+				switch b.Type {
+				case BlockLinkCard:
+					add(b.URL, LinkCard, b.Title, b.Description)
+				case BlockVideo:
+					add(b.URL, LinkVideo, b.Title, b.Description)
+				case BlockMap:
+					u := b.URL
+					if u == "" && b.Attrs["places.0.placeId"] != "" {
+						u = "https://map.naver.com/p/entry/place/" + b.Attrs["places.0.placeId"]
+					}
+					add(u, LinkMapPlace, firstNonEmpty(b.Title, b.Attrs["places.0.name"]), firstNonEmpty(b.Description, b.Attrs["places.0.address"]))
+				}
+			}
+		case s.Closest(embedded).Length() > 0:
+			return
+		case goquery.NodeName(s) == "iframe":
+			add(s.AttrOr("src", ""), LinkVideo, s.AttrOr("title", ""), "")
+		default:
+			if _, download := s.Attr("download"); download || s.HasClass("se-file-save-button") || s.HasClass("se-file-save") {
+				return
+			}
+			text := oneLine(selectionText(s))
+			if img := s.Find("img").First(); img.Length() > 0 {
+				// 이미지에 걸린 링크는 data-linkdata 에 실제 주소가 있음
+				if link := imageLink(img); link != "" {
+					add(link, LinkAnchor, firstNonEmpty(text, img.AttrOr("alt", "")), "")
+					return
+				}
+			}
+			if s.HasClass("se-module-image-link") {
+				// 링크를 걸지 않은 이미지 (원본 이미지 보기)
+				return
+			}
+			add(s.AttrOr("href", ""), LinkAnchor, text, s.AttrOr("title", ""))
+		}
+	})
+	return links
+}
+
+// 주소를 감싸는 리다이렉트 래퍼: 호스트 → (경로, 실제 주소가 담긴 쿼리 파라미터)
+var redirectWrappers = map[string]struct{ path, param string }{
+	"link.naver.com":  {"/bridge", "url"},
+	"www.google.com":  {"/url", "q"},
+	"google.com":      {"/url", "q"},
+	"l.facebook.com":  {"/l.php", "u"},
+	"lm.facebook.com": {"/l.php", "u"},
+	"l.instagram.com": {"", "u"},
+	"www.youtube.com": {"/redirect", "q"},
+}
+
+// 리다이렉트 래퍼를 여러 겹 벗길 때의 최대 횟수
+const maxUnwrap = 5
+
+// CanonicalURL 은 링크 주소를 비교 가능한 형태로 정리합니다.
+//   - // 로 시작하면 https: 를 붙이고, http(s) 가 아니면 빈 문자열을 반환
+//   - 쿼리 파라미터로 실제 주소를 전달하는 리다이렉트 래퍼(link.naver.com/bridge 등)를 벗김
+//   - YouTube(youtu.be 포함), 네이버 TV 임베드 주소는 시청 페이지 주소로 바꿈
+//
+// 단축 URL 처럼 요청해야만 알 수 있는 리다이렉트는 그대로 둡니다.
+func CanonicalURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "//") {
+		raw = "https:" + raw
+	}
+	for i := 0; i <= maxUnwrap; i++ {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ""
+		}
+		target := unwrap(u)
+		if target == "" {
+			return embedPage(u).String()
+		}
+		raw = target
+	}
+	return raw
+}
+
+// 리다이렉트 래퍼면 실제 주소, 아니면 빈 문자열
+func unwrap(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	w, ok := redirectWrappers[host]
+	if ok && (w.path == "" || u.Path == w.path) {
+		return u.Query().Get(w.param)
+	}
+	// 그 밖의 네이버 중계 주소도 url 파라미터에 외부 주소를 담는 경우가 많음
+	if strings.HasSuffix(host, ".naver.com") {
+		for _, key := range []string{"url", "u", "target"} {
+			if v := u.Query().Get(key); strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "https://") {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+// 임베드 플레이어, youtu.be 단축 주소를 시청 페이지 주소로 변환
+func embedPage(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	dir, id := path.Split(u.Path)
+	switch {
+	case (host == "www.youtube.com" || host == "youtube.com" || host == "www.youtube-nocookie.com") && dir == "/embed/" && id != "":
+		return &url.URL{Scheme: "https", Host: "www.youtube.com", Path: "/watch", RawQuery: "v=" + url.QueryEscape(id)}
+	case host == "youtu.be" && dir == "/" && id != "":
+		return &url.URL{Scheme: "https", Host: "www.youtube.com", Path: "/watch", RawQuery: "v=" + url.QueryEscape(id)}
+	case host == "tv.naver.com" && dir == "/embed/" && id != "":
+		return &url.URL{Scheme: "https", Host: "tv.naver.com", Path: "/v/" + id}
+	}
+	return u
+}
+
+func domain(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,92 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"일반 주소", " https://example.com/a?b=1#c ", "https://example.com/a?b=1#c"},
+		{"스킴 생략", "//example.com/a", "https://example.com/a"},
+		{"http 가 아님", "javascript:void(0)", ""},
+		{"mailto", "mailto:a@example.com", ""},
+		{"상대 경로", "/ArticleRead.nhn?articleid=1", ""},
+		{"빈 문자열", "", ""},
+		{"네이버 링크 브리지", "https://link.naver.com/bridge?url=https%3A%2F%2Fexample.com%2Fpost%3Fid%3D1&dst=naversearchapp", "https://example.com/post?id=1"},
+		{"구글 리다이렉트", "https://www.google.com/url?q=https://example.com/&sa=D", "https://example.com/"},
+		{"구글 검색은 그대로", "https://www.google.com/search?q=https://example.com/", "https://www.google.com/search?q=https://example.com/"},
+		{"페이스북", "https://l.facebook.com/l.php?u=https%3A%2F%2Fexample.com%2F&h=AT0", "https://example.com/"},
+		{"여러 겹", "https://link.naver.com/bridge?url=" +
+			"https%3A%2F%2Fl.facebook.com%2Fl.php%3Fu%3Dhttps%253A%252F%252Fexample.com%252Fdeep", "https://example.com/deep"},
+		{"그 밖의 네이버 중계 주소", "https://cafe.naver.com/linkout?target=http://example.com/x", "http://example.com/x"},
+		{"래퍼 안의 잘못된 주소", "https://link.naver.com/bridge?url=javascript:alert(1)", ""},
+		{"YouTube 임베드", "https://www.youtube.com/embed/dQw4w9WgXcQ?autoplay=1", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"youtube-nocookie 임베드", "//www.youtube-nocookie.com/embed/dQw4w9WgXcQ", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"youtu.be", "https://youtu.be/dQw4w9WgXcQ?t=10", "https://www.youtube.com/watch?v=dQw4w9WgXcQ"},
+		{"YouTube 리다이렉트", "https://www.youtube.com/redirect?q=https%3A%2F%2Fexample.com%2F&v=x", "https://example.com/"},
+		{"네이버 TV 임베드", "https://tv.naver.com/embed/12345?autoPlay=true", "https://tv.naver.com/v/12345"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanonicalURL(tt.raw); got != tt.want {
+				t.Errorf("CanonicalURL(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractLinks(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []Link
+	}{
+		{"빈 본문", "", nil},
+		{
+			name: "일반 링크, 중복, 제외 대상",
+			html: `<p><a href="https://link.naver.com/bridge?url=https%3A%2F%2Fwww.example.com%2Fa">첫 링크</a>
+				<a href="https://www.example.com/a" title="설명">다시</a>
+				<a href="#top">위로</a><a href="mailto:a@example.com">메일</a>
+				<a href="https://cafeattach.naver.net/a.pdf" download>첨부</a></p>`,
+			want: []Link{{URL: "https://www.example.com/a", Kind: LinkAnchor, Domain: "example.com", Title: "첫 링크", Description: "설명"}},
+		},
+		{
+			name: "이미지 링크",
+			html: `<div class="se-component se-image">
+				<a class="se-module-image-link" href="#" data-linkdata='{"linkUse":"true","link":"https://shop.example.com/item"}'><img src="https://postfiles.pstatic.net/1.jpg" alt="상품"></a>
+				<a class="se-module-image-link" href="#" data-linkdata='{"linkUse":"false"}'><img src="https://postfiles.pstatic.net/2.jpg"></a>
+			</div>`,
+			want: []Link{{URL: "https://shop.example.com/item", Kind: LinkAnchor, Domain: "shop.example.com", Title: "상품"}},
+		},
+		{
+			name: "링크 카드와 임베드",
+			html: `<div class="se-component se-oglink">
+					<a class="se-oglink-info" href="https://example.com/post"><strong class="se-oglink-title">제목</strong><p class="se-oglink-summary">요약</p></a>
+				</div>
+				<iframe src="https://www.youtube.com/embed/dQw4w9WgXcQ" title="영상"></iframe>`,
+			want: []Link{
+				{URL: "https://example.com/post", Kind: LinkCard, Domain: "example.com", Title: "제목", Description: "요약"},
+				{URL: "https://www.youtube.com/watch?v=dQw4w9WgXcQ", Kind: LinkVideo, Domain: "youtube.com", Title: "영상"},
+			},
+		},
+		{
+			name: "지도 장소",
+			html: `<div class="se-component se-placesMap">
+				<script class="__se_module_data" data-module='{"data":{"places":[{"placeId":"1234","name":"가게","address":"서울"}]}}'></script>
+			</div>`,
+			want: []Link{{URL: "https://map.naver.com/p/entry/place/1234", Kind: LinkMapPlace, Domain: "map.naver.com", Title: "가게", Description: "서울"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExtractLinks(tt.html); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractLinks()\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
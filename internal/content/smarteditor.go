@@ -0,0 +1,438 @@
+package content
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// BlockType 은 SmartEditor 컴포넌트(블록) 종류입니다.
+type BlockType string
+
+const (
+	BlockParagraph BlockType = "paragraph"
+	BlockHeading   BlockType = "heading"
+	BlockImage     BlockType = "image"
+	BlockQuote     BlockType = "quote"
+	BlockLinkCard  BlockType = "link_card"
+	BlockTable     BlockType = "table"
+	BlockVideo     BlockType = "video"
+	BlockMap       BlockType = "map"
+	BlockSticker   BlockType = "sticker"
+	BlockCode      BlockType = "code"
+	BlockDivider   BlockType = "divider"
+	BlockFile      BlockType = "file"
+)
+
+// Block 은 본문을 이루는 블록 하나입니다. 종류에 따라 쓰는 필드가 다릅니다.
+//
+//   - paragraph, heading, quote, code: Text
+//   - image, sticker: URL (이미지 주소), Text (캡션)
+//   - link_card: URL, Title, Description, Thumbnail
+//   - video: URL (iframe/동영상 주소), Title, Description, Thumbnail, Text (캡션)
+//   - map: URL (지도 링크), Title (장소 이름), Description (주소)
+//   - table: Rows
+//   - file: URL (내려받기 주소), Title (파일 이름)
+//
+// 그 밖의 속성(인용 출처, 이미지 크기, 동영상 vid 등 컴포넌트 데이터)은 Attrs 에 담습니다.
+type Block struct {
+	Type        BlockType         `json:"type"`
+	Text        string            `json:"text,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Thumbnail   string            `json:"thumbnail,omitempty"`
+	Rows        [][]string        `json:"rows,omitempty"`
+	Attrs       map[string]string `json:"attrs,omitempty"`
+}
+
+// ParseBlocks 는 SmartEditor ONE 본문(.se-component)을 등장 순서대로 블록 목록으로 나눕니다.
+// 카페 본문과 블로그 .se-main-container 모두 같은 마크업을 사용합니다.
+// SmartEditor 컴포넌트가 없는 예전 본문은 최상위 요소 단위로 문단, 이미지, 표, 인용을 찾습니다.
+func ParseBlocks(htmlContent string) []Block {
+	if strings.TrimSpace(htmlContent) == "" {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	components := doc.Find(".se-component").FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return s.Parent().Closest(".se-component").Length() == 0
+	})
+	if components.Length() == 0 {
+		return legacyBlocks(doc)
+	}
+
+	var blocks []Block
+	components.Each(func(_ int, s *goquery.Selection) {
+		blocks = append(blocks, componentBlocks(s)...)
+	})
+	return blocks
+}
+
+// 컴포넌트 하나를 블록으로 변환 (텍스트 컴포넌트는 문단마다, 이미지 묶음은 이미지마다 블록 하나)
+func componentBlocks(s *goquery.Selection) []Block {
+	data := moduleData(s)
+	switch {
+	case s.HasClass("se-text"):
+		return textBlocks(s)
+	case s.HasClass("se-documentTitle"), s.HasClass("se-sectionTitle"):
+		return nonEmpty(Block{Type: BlockHeading, Text: selectionText(s.Find(".se-module-text").First())})
+	case s.HasClass("se-image"), s.HasClass("se-imageStrip"), s.HasClass("se-imageGroup"), s.HasClass("se-imageSlide"):
+		return imageBlocks(s)
+	case s.HasClass("se-sticker"):
+		b := Block{Type: BlockSticker, Attrs: data}
+		if img := s.Find("img").First(); img.Length() > 0 {
+			b.URL = ImageSource(img.Get(0))
+		}
+		return []Block{b}
+	case s.HasClass("se-quotation"):
+		b := Block{Type: BlockQuote, Text: selectionText(s.Find(".se-quote").First()), Attrs: data}
+		if cite := selectionText(s.Find(".se-cite").First()); cite != "" {
+			b.Attrs = setAttr(b.Attrs, "cite", cite)
+		}
+		return nonEmpty(b)
+	case s.HasClass("se-oglink"):
+		b := Block{
+			Type:        BlockLinkCard,
+			URL:         firstAttr(s.Find("a.se-oglink-info, a.se-oglink-thumbnail, a"), "href"),
+			Title:       selectionText(s.Find(".se-oglink-title").First()),
+			Description: selectionText(s.Find(".se-oglink-summary").First()),
+			Thumbnail:   firstImage(s),
+			Attrs:       data,
+		}
+		if domain := selectionText(s.Find(".se-oglink-url").First()); domain != "" {
+			b.Attrs = setAttr(b.Attrs, "domain", domain)
+		}
+		return []Block{b}
+	case s.HasClass("se-table"):
+		return nonEmpty(Block{Type: BlockTable, Rows: tableRows(s)})
+	case s.HasClass("se-video"), s.HasClass("se-oembed"):
+		b := Block{
+			Type:      BlockVideo,
+			URL:       firstAttr(s.Find("iframe, video, video source"), "src"),
+			Title:     selectionText(s.Find(".se-video-title, .se-oembed-title, .se-module-oembed .title").First()),
+			Thumbnail: firstImage(s),
+			Text:      selectionText(s.Find(".se-caption").First()),
+			Attrs:     data,
+		}
+		// oembed(YouTube 등)는 원래 주소와 제목을 컴포넌트 데이터에 담음
+		b.URL = firstNonEmpty(b.URL, data["inputUrl"], data["url"])
+		b.Title = firstNonEmpty(b.Title, data["title"], data["mediaMeta.title"])
+		b.Description = data["description"]
+		b.Thumbnail = firstNonEmpty(b.Thumbnail, data["thumbnailUrl"], data["thumbnail"])
+		return []Block{b}
+	case s.HasClass("se-map"), s.HasClass("se-placesMap"):
+		return []Block{{
+			Type:        BlockMap,
+			URL:         firstAttr(s.Find("a.se-map-info, a"), "href"),
+			Title:       selectionText(s.Find(".se-map-title").First()),
+			Description: selectionText(s.Find(".se-map-address").First()),
+			Thumbnail:   firstImage(s),
+			Attrs:       data,
+		}}
+	case s.HasClass("se-code"):
+		code := s.Find(".se-code-source, pre, code").First()
+		b := Block{Type: BlockCode, Attrs: data}
+		if code.Length() > 0 {
+			b.Text = strings.Trim(invisibleReplacer.Replace(textContent(code.Get(0))), "\n")
+			for _, class := range strings.Fields(code.AttrOr("class", "")) {
+				if lang, ok := strings.CutPrefix(class, "language-"); ok {
+					b.Attrs = setAttr(b.Attrs, "language", lang)
+				}
+			}
+		}
+		return nonEmpty(b)
+	case s.HasClass("se-horizontalLine"):
+		return []Block{{Type: BlockDivider}}
+	case s.HasClass("se-file"):
+		name := selectionText(s.Find(".se-file-name").First()) + selectionText(s.Find(".se-file-extension").First())
+		return []Block{{
+			Type:  BlockFile,
+			URL:   firstAttr(s.Find("a.se-file-save-button, a.se-file-save, a[download]"), "href"),
+			Title: name,
+			Attrs: data,
+		}}
+	default:
+		// 알 수 없는 컴포넌트는 클래스 이름(se- 제외)을 종류로 하고 텍스트만 담음
+		return nonEmpty(Block{Type: BlockType(componentName(s)), Text: selectionText(s), Attrs: data})
+	}
+}
+
+// se-text 컴포넌트: 문단마다 블록 하나, 목록 항목이면 list 속성 추가
+func textBlocks(s *goquery.Selection) []Block {
+	paragraphs := s.Find(".se-text-paragraph")
+	if paragraphs.Length() == 0 {
+		return nonEmpty(Block{Type: BlockParagraph, Text: selectionText(s)})
+	}
+	var blocks []Block
+	paragraphs.Each(func(_ int, p *goquery.Selection) {
+		b := Block{Type: BlockParagraph, Text: selectionText(p)}
+		if b.Text == "" {
+			return
+		}
+		if list := p.Closest("ul, ol"); list.Length() > 0 {
+			b.Attrs = setAttr(b.Attrs, "list", goquery.NodeName(list))
+		}
+		blocks = append(blocks, b)
+	})
+	return blocks
+}
+
+// 이미지 컴포넌트: 이미지마다 블록 하나. 캡션과 링크는 컴포넌트 단위로 공유
+func imageBlocks(s *goquery.Selection) []Block {
+	caption := selectionText(s.Find(".se-caption").First())
+	var blocks []Block
+	s.Find("img").Each(func(_ int, img *goquery.Selection) {
+		src := ImageSource(img.Get(0))
+		if src == "" {
+			return
+		}
+		b := Block{Type: BlockImage, URL: src, Text: caption}
+		for _, key := range []string{"width", "height", "alt"} {
+			if v := strings.TrimSpace(img.AttrOr(key, "")); v != "" {
+				b.Attrs = setAttr(b.Attrs, key, v)
+			}
+		}
+		if link := imageLink(img); link != "" {
+			b.Attrs = setAttr(b.Attrs, "link", link)
+		}
+		blocks = append(blocks, b)
+	})
+	if len(blocks) > 0 {
+		return blocks
+	}
+
+	// GIF 는 mp4 동영상으로 들어 있음
+	video := s.Find("video")
+	src := video.AttrOr("data-gif-url", firstAttr(s.Find("video, video source"), "src"))
+	if src == "" {
+		return nil
+	}
+	return []Block{{Type: BlockImage, URL: src, Text: caption, Attrs: map[string]string{"format": "gif"}}}
+}
+
+// 이미지에 걸린 링크 (data-linkdata 의 linkUse/link)
+func imageLink(img *goquery.Selection) string {
+	raw, ok := img.Closest("a.se-module-image-link").Attr("data-linkdata")
+	if !ok {
+		return ""
+	}
+	var linkData struct {
+		LinkUse string `json:"linkUse"`
+		Link    string `json:"link"`
+	}
+	if err := json.Unmarshal([]byte(raw), &linkData); err != nil || linkData.LinkUse != "true" {
+		return ""
+	}
+	return linkData.Link
+}
+
+func tableRows(s *goquery.Selection) [][]string {
+	var rows [][]string
+	s.Find("tr").Each(func(_ int, tr *goquery.Selection) {
+		var cells []string
+		tr.Find("td, th").Each(func(_ int, td *goquery.Selection) {
+			cells = append(cells, oneLine(selectionText(td)))
+		})
+		if len(cells) > 0 {
+			rows = append(rows, cells)
+		}
+	})
+	return rows
+}
+
+// 컴포넌트 데이터 스크립트(<script class="__se_module_data" data-module='{...}'>)의
+// data 값을 평탄화한 속성. 중첩된 값은 "places.0.name" 처럼 점으로 이은 키를 사용합니다.
+func moduleData(s *goquery.Selection) map[string]string {
+	script := s.Find("script.__se_module_data").First()
+	raw := script.AttrOr("data-module-v2", script.AttrOr("data-module", ""))
+	if raw == "" {
+		return nil
+	}
+	var module struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(raw), &module); err != nil || len(module.Data) == 0 {
+		return nil
+	}
+	attrs := make(map[string]string)
+	flatten(attrs, "", module.Data, 0)
+	if len(attrs) == 0 {
+		return nil
+	}
+	return attrs
+}
+
+// 너무 깊이 중첩된 값(미디어 메타데이터 등)은 생략
+const maxModuleDataDepth = 4
+
+func flatten(attrs map[string]string, prefix string, v interface{}, depth int) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if depth >= maxModuleDataDepth {
+			return
+		}
+		for k, child := range v {
+			flatten(attrs, joinKey(prefix, k), child, depth+1)
+		}
+	case []interface{}:
+		if depth >= maxModuleDataDepth {
+			return
+		}
+		for i, child := range v {
+			flatten(attrs, joinKey(prefix, fmt.Sprint(i)), child, depth+1)
+		}
+	case string:
+		if v != "" {
+			attrs[prefix] = v
+		}
+	case nil:
+	default:
+		attrs[prefix] = fmt.Sprint(v)
+	}
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
+
+// SmartEditor 컴포넌트가 없는 본문: 최상위 블록 요소마다 블록으로 변환
+// 블록 요소 사이의 텍스트와 인라인 요소는 하나로 모아 문단으로 만듭니다.
+func legacyBlocks(doc *goquery.Document) []Block {
+	var (
+		blocks []Block
+		inline []*html.Node
+	)
+	r := renderer{}
+	paragraphs := func(nodes ...*html.Node) {
+		var b strings.Builder
+		for _, n := range nodes {
+			b.WriteString(r.node(n))
+		}
+		for _, para := range strings.Split(normalize(b.String()), "\n\n") {
+			blocks = append(blocks, nonEmpty(Block{Type: BlockParagraph, Text: para})...)
+		}
+		for _, n := range nodes {
+			goquery.NewDocumentFromNode(n).Find("img").AddBack().Filter("img").Each(func(_ int, img *goquery.Selection) {
+				if src := ImageSource(img.Get(0)); src != "" {
+					blocks = append(blocks, Block{Type: BlockImage, URL: src})
+				}
+			})
+		}
+	}
+	flush := func() {
+		paragraphs(inline...)
+		inline = nil
+	}
+
+	for _, body := range doc.Find("body").Nodes {
+		for n := body.FirstChild; n != nil; n = n.NextSibling {
+			if n.Type != html.ElementNode || !isBlockElement(n) {
+				inline = append(inline, n)
+				continue
+			}
+			flush()
+			switch n.DataAtom {
+			case atom.Table:
+				blocks = append(blocks, nonEmpty(Block{Type: BlockTable, Rows: tableRows(goquery.NewDocumentFromNode(n).Selection)})...)
+			case atom.Blockquote:
+				blocks = append(blocks, nonEmpty(Block{Type: BlockQuote, Text: normalize(r.children(n))})...)
+			case atom.Pre:
+				blocks = append(blocks, nonEmpty(Block{Type: BlockCode, Text: strings.Trim(textContent(n), "\n")})...)
+			case atom.Hr:
+				blocks = append(blocks, Block{Type: BlockDivider})
+			case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+				blocks = append(blocks, nonEmpty(Block{Type: BlockHeading, Text: oneLine(r.children(n))})...)
+			default:
+				paragraphs(n)
+			}
+		}
+	}
+	flush()
+	return blocks
+}
+
+func isBlockElement(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Figure, atom.Ul, atom.Ol,
+		atom.Table, atom.Blockquote, atom.Pre, atom.Hr,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		return true
+	}
+	return false
+}
+
+// 내용이 없는 블록은 빈 목록
+func nonEmpty(b Block) []Block {
+	if b.Text == "" && len(b.Rows) == 0 {
+		return nil
+	}
+	return []Block{b}
+}
+
+// 선택 영역의 텍스트 (줄바꿈 유지, 공백 정리)
+func selectionText(s *goquery.Selection) string {
+	r := renderer{}
+	var b strings.Builder
+	for _, n := range s.Nodes {
+		b.WriteString(r.children(n))
+	}
+	return normalize(b.String())
+}
+
+func firstAttr(s *goquery.Selection, key string) string {
+	var value string
+	s.EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		value = strings.TrimSpace(sel.AttrOr(key, ""))
+		return value == ""
+	})
+	return value
+}
+
+func firstImage(s *goquery.Selection) string {
+	var src string
+	s.Find("img").EachWithBreak(func(_ int, img *goquery.Selection) bool {
+		src = ImageSource(img.Get(0))
+		return src == ""
+	})
+	return src
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func setAttr(attrs map[string]string, key, value string) map[string]string {
+	if attrs == nil {
+		attrs = make(map[string]string)
+	}
+	attrs[key] = value
+	return attrs
+}
+
+// se-component 의 종류 클래스 이름 (se-component, se-l-*, se-section-* 등 공통 클래스 제외)
+func componentName(s *goquery.Selection) string {
+	for _, class := range strings.Fields(s.AttrOr("class", "")) {
+		name, ok := strings.CutPrefix(class, "se-")
+		if !ok || name == "component" || strings.HasPrefix(name, "l-") || strings.HasPrefix(name, "section") {
+			continue
+		}
+		return name
+	}
+	return "unknown"
+}
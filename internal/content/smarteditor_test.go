@@ -0,0 +1,123 @@
+package content
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBlocks(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []Block
+	}{
+		{"빈 본문", "", nil},
+		{
+			name: "문단과 목록",
+			html: `<div class="se-component se-text se-l-default"><div class="se-module se-module-text">
+				<p class="se-text-paragraph"><span>첫 문단</span></p>
+				<p class="se-text-paragraph"><span>&#8203;</span></p>
+				<ul><li><p class="se-text-paragraph">항목</p></li></ul>
+			</div></div>`,
+			want: []Block{
+				{Type: BlockParagraph, Text: "첫 문단"},
+				{Type: BlockParagraph, Text: "항목", Attrs: map[string]string{"list": "ul"}},
+			},
+		},
+		{
+			name: "제목",
+			html: `<div class="se-component se-documentTitle"><div class="se-module se-module-text"><p>글 <b>제목</b></p></div></div>`,
+			want: []Block{{Type: BlockHeading, Text: "글 제목"}},
+		},
+		{
+			name: "이미지 묶음과 링크",
+			html: `<div class="se-component se-imageStrip">
+				<a class="se-module-image-link" data-linkdata='{"linkUse":"true","link":"https://example.com/shop"}'>
+					<img src="data:image/gif;base64,R0lG" data-lazy-src="https://postfiles.pstatic.net/1.jpg" width="800" height="600"></a>
+				<a class="se-module-image-link" data-linkdata='{"linkUse":"false","link":""}'>
+					<img src="https://postfiles.pstatic.net/2.jpg" alt="둘째"></a>
+				<div class="se-caption"><p>사진 설명</p></div>
+			</div>`,
+			want: []Block{
+				{Type: BlockImage, URL: "https://postfiles.pstatic.net/1.jpg", Text: "사진 설명",
+					Attrs: map[string]string{"width": "800", "height": "600", "link": "https://example.com/shop"}},
+				{Type: BlockImage, URL: "https://postfiles.pstatic.net/2.jpg", Text: "사진 설명",
+					Attrs: map[string]string{"alt": "둘째"}},
+			},
+		},
+		{
+			name: "GIF 동영상",
+			html: `<div class="se-component se-image"><video data-gif-url="https://mblogvideo-phinf.pstatic.net/a.gif"><source src="https://mblogvideo-phinf.pstatic.net/a.mp4"></video></div>`,
+			want: []Block{{Type: BlockImage, URL: "https://mblogvideo-phinf.pstatic.net/a.gif", Attrs: map[string]string{"format": "gif"}}},
+		},
+		{
+			name: "인용",
+			html: `<div class="se-component se-quotation"><blockquote class="se-quotation-container">
+				<div class="se-quote"><p>인용문</p></div><div class="se-cite"><p>출처</p></div>
+			</blockquote></div>`,
+			want: []Block{{Type: BlockQuote, Text: "인용문", Attrs: map[string]string{"cite": "출처"}}},
+		},
+		{
+			name: "링크 카드",
+			html: `<div class="se-component se-oglink">
+				<script type="text/data" class="__se_module_data" data-module='{"type":"v2_oglink","data":{"link":"https://example.com/post","isVideo":false}}'></script>
+				<a class="se-oglink-thumbnail" href="https://example.com/post"><img src="https://dthumb-phinf.pstatic.net/t.jpg"></a>
+				<a class="se-oglink-info" href="https://example.com/post">
+					<strong class="se-oglink-title">제목</strong><p class="se-oglink-summary">요약</p><p class="se-oglink-url">example.com</p></a>
+			</div>`,
+			want: []Block{{Type: BlockLinkCard, URL: "https://example.com/post", Title: "제목", Description: "요약",
+				Thumbnail: "https://dthumb-phinf.pstatic.net/t.jpg",
+				Attrs:     map[string]string{"link": "https://example.com/post", "isVideo": "false", "domain": "example.com"}}},
+		},
+		{
+			name: "oembed 동영상",
+			html: `<div class="se-component se-oembed">
+				<script class="__se_module_data" data-module='{"data":{"inputUrl":"https://youtu.be/abc","title":"영상","thumbnailUrl":"https://i.ytimg.com/vi/abc/0.jpg"}}'></script>
+				<div class="se-caption"><p>캡션</p></div>
+			</div>`,
+			want: []Block{{Type: BlockVideo, URL: "https://youtu.be/abc", Title: "영상", Thumbnail: "https://i.ytimg.com/vi/abc/0.jpg", Text: "캡션",
+				Attrs: map[string]string{"inputUrl": "https://youtu.be/abc", "title": "영상", "thumbnailUrl": "https://i.ytimg.com/vi/abc/0.jpg"}}},
+		},
+		{
+			name: "표, 코드, 구분선, 파일",
+			html: `<div class="se-component se-table"><table><tr><td><p>a</p></td><td><p>b  c</p></td></tr></table></div>
+				<div class="se-component se-code"><pre class="se-code-source language-go">fmt.Println(1)
+</pre></div>
+				<div class="se-component se-horizontalLine"><hr></div>
+				<div class="se-component se-file"><span class="se-file-name">자료</span><span class="se-file-extension">.pdf</span>
+					<a class="se-file-save-button" href="https://cafeattach.naver.net/a.pdf">받기</a></div>`,
+			want: []Block{
+				{Type: BlockTable, Rows: [][]string{{"a", "b c"}}},
+				{Type: BlockCode, Text: "fmt.Println(1)", Attrs: map[string]string{"language": "go"}},
+				{Type: BlockDivider},
+				{Type: BlockFile, URL: "https://cafeattach.naver.net/a.pdf", Title: "자료.pdf"},
+			},
+		},
+		{
+			name: "알 수 없는 컴포넌트",
+			html: `<div class="se-component se-l-default se-poll"><p>투표</p></div>`,
+			want: []Block{{Type: "poll", Text: "투표"}},
+		},
+		{
+			name: "예전 본문",
+			html: `안녕하세요<br>반갑습니다<h3>소제목</h3><p><img src="https://cafeptthumb-phinf.pstatic.net/a.png"></p>
+				<blockquote>인용</blockquote><table><tr><td>x</td></tr></table><hr><pre>code</pre>`,
+			want: []Block{
+				{Type: BlockParagraph, Text: "안녕하세요\n반갑습니다"},
+				{Type: BlockHeading, Text: "소제목"},
+				{Type: BlockImage, URL: "https://cafeptthumb-phinf.pstatic.net/a.png"},
+				{Type: BlockQuote, Text: "인용"},
+				{Type: BlockTable, Rows: [][]string{{"x"}}},
+				{Type: BlockDivider},
+				{Type: BlockCode, Text: "code"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseBlocks(tt.html); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBlocks()\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package crawling
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"naverCafeCrawler/internal/output"
+)
+
+// FetchArticle 은 카페 게시글 하나를 본문과 모든 댓글(답글 포함)까지 가져옵니다.
+// WithMediaDir 를 지정했으면 미디어도 내려받습니다.
+func (c *Crawler) FetchArticle(ctx context.Context, cafeId string, articleId int) (CafeArticle, error) {
+	article, err := c.getArticleDetail(ctx, cafeId, articleId)
+	if err != nil {
+		if ctx.Err() != nil {
+			return CafeArticle{}, ctx.Err()
+		}
+		return CafeArticle{}, fmt.Errorf("게시글 %d 가져오기 실패: %w", articleId, err)
+	}
+	return article, nil
+}
+
+// CrawlArticle 은 카페 게시글 하나를 가져와 출력 Sink(기본값 JSON 배열)에 저장합니다.
+// 파일 이름은 cafe_{카페ID}_article_{게시글ID}_{타임스탬프} 입니다.
+func (c *Crawler) CrawlArticle(ctx context.Context, cafeId string, articleId int) (CafeArticle, error) {
+	log.Printf("📖 카페 %s 게시글 %d 가져오는 중...", cafeId, articleId)
+
+	article, err := c.FetchArticle(ctx, cafeId, articleId)
+	if err != nil {
+		return CafeArticle{}, err
+	}
+
+	outputDir := c.outputDir
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return article, fmt.Errorf("출력 디렉토리 생성 실패: %v", err)
+	}
+	timestamp := time.Now().Format("20060102_150405")
+	format := c.formatOr(output.FormatJSON)
+	base := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_article_%d_%s", cafeId, articleId, timestamp))
+
+	sink, err := output.New(format, base)
+	if err != nil {
+		return article, err
+	}
+	if err := sink.Open(); err != nil {
+		return article, fmt.Errorf("결과 저장 실패: %v", err)
+	}
+	record, comments := article.sinkRecords(cafeId, "article")
+	if err := output.WriteArticleWithComments(sink, record, comments); err != nil {
+		sink.Close()
+		return article, fmt.Errorf("결과 저장 실패: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		return article, fmt.Errorf("결과 저장 실패: %v", err)
+	}
+	log.Printf("💾 저장 완료: %s", output.Path(format, base))
+	return article, nil
+}
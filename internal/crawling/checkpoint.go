@@ -0,0 +1,160 @@
+package crawling
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"naverCafeCrawler/internal/output"
+)
+
+// 게시판 크롤링 진행 상황 (cafeId, boardID 별로 하나)
+type boardCheckpoint struct {
+	CafeID           string        `json:"cafe_id"`
+	BoardID          string        `json:"board_id"`
+	Timestamp        string        `json:"timestamp"`     // 출력 파일명에 쓰이는 타임스탬프
+	Format           output.Format `json:"format"`        // 전체 결과 출력 형식
+	OutputBase       string        `json:"output_base"`   // 전체 결과 경로 (확장자 제외)
+	FullFilename     string        `json:"full_filename"` // 전체 결과 대표 파일 경로
+	CompletedPages   []int         `json:"completed_pages"`
+	FinishedArticles []int         `json:"finished_articles"`
+	// 출력 파일별로 완전한 행까지 기록된 위치 (CSV 처럼 줄 단위로 복구할 수 없는 형식만)
+	OutputOffsets map[string]int64 `json:"output_offsets,omitempty"`
+	UpdatedAt     string           `json:"updated_at"`
+
+	path     string
+	resumed  bool
+	offsets  func() map[string]int64 // 저장할 때 OutputOffsets 를 가져올 함수
+	mu       sync.Mutex
+	pages    map[int]bool
+	articles map[int]bool
+}
+
+func checkpointPath(outputDir, cafeId, boardID string) string {
+	return filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s.checkpoint.json", cafeId, boardID))
+}
+
+// 체크포인트 불러오기
+// resume 이 false 이거나 파일이 없으면 새 체크포인트를 만듭니다.
+func loadCheckpoint(outputDir, cafeId, boardID string, format output.Format, resume bool) (*boardCheckpoint, error) {
+	path := checkpointPath(outputDir, cafeId, boardID)
+
+	if resume {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			cp := &boardCheckpoint{}
+			if err := json.Unmarshal(data, cp); err != nil {
+				return nil, fmt.Errorf("체크포인트 파싱 실패: %v", err)
+			}
+			cp.path = path
+			cp.resumed = true
+			cp.pages = toSet(cp.CompletedPages)
+			cp.articles = toSet(cp.FinishedArticles)
+			return cp, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("체크포인트 읽기 실패: %v", err)
+		}
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	base := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_full", cafeId, boardID, timestamp))
+	return &boardCheckpoint{
+		CafeID:       cafeId,
+		BoardID:      boardID,
+		Timestamp:    timestamp,
+		Format:       format,
+		OutputBase:   base,
+		FullFilename: output.Path(format, base),
+		path:         path,
+		pages:        make(map[int]bool),
+		articles:     make(map[int]bool),
+	}, nil
+}
+
+func (cp *boardCheckpoint) isPageDone(page int) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.pages[page]
+}
+
+func (cp *boardCheckpoint) isArticleDone(articleId int) bool {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.articles[articleId]
+}
+
+func (cp *boardCheckpoint) markPage(page int) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.pages[page] = true
+}
+
+func (cp *boardCheckpoint) markArticle(articleId int) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.articles[articleId] = true
+}
+
+// 출력에 실제로 기록된 게시글로 완료 목록 교체
+func (cp *boardCheckpoint) resetArticles(ids map[string]bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.articles = make(map[int]bool, len(ids))
+	for id := range ids {
+		if n, err := strconv.Atoi(id); err == nil {
+			cp.articles[n] = true
+		}
+	}
+}
+
+func (cp *boardCheckpoint) articleCount() int {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return len(cp.articles)
+}
+
+// 체크포인트 파일 저장
+func (cp *boardCheckpoint) save() error {
+	cp.mu.Lock()
+	cp.CompletedPages = fromSet(cp.pages)
+	cp.FinishedArticles = fromSet(cp.articles)
+	if cp.offsets != nil {
+		cp.OutputOffsets = cp.offsets()
+	}
+	cp.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	err := output.SaveJSON(cp, cp.path)
+	cp.mu.Unlock()
+	return err
+}
+
+// 크롤링이 끝나면 체크포인트 삭제
+func (cp *boardCheckpoint) remove() error {
+	if err := os.Remove(cp.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func toSet(ids []int) map[int]bool {
+	set := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func fromSet(set map[int]bool) []int {
+	ids := make([]int, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
@@ -0,0 +1,125 @@
+package crawling
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"naverCafeCrawler/internal/output"
+)
+
+func TestCheckpointSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := loadCheckpoint(dir, "1", "2", output.FormatCSV, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cp.resumed {
+		t.Error("체크포인트 파일이 없는데 재개로 표시했습니다")
+	}
+	cp.markPage(3)
+	cp.markPage(1)
+	cp.markArticle(20)
+	cp.markArticle(10)
+	cp.offsets = func() map[string]int64 { return map[string]int64{"articles.csv": 42} }
+	if err := cp.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	// resume 이 false 이면 저장된 체크포인트를 무시하고 새로 시작
+	fresh, err := loadCheckpoint(dir, "1", "2", output.FormatCSV, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fresh.resumed || fresh.isPageDone(1) {
+		t.Errorf("resume=false 인데 체크포인트를 불러왔습니다: %+v", fresh)
+	}
+
+	got, err := loadCheckpoint(dir, "1", "2", output.FormatJSONL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.resumed || got.Format != output.FormatCSV || got.FullFilename != cp.FullFilename {
+		t.Errorf("불러온 체크포인트 = %+v, want 저장한 출력 형식과 경로", got)
+	}
+	if !reflect.DeepEqual(got.CompletedPages, []int{1, 3}) || !reflect.DeepEqual(got.FinishedArticles, []int{10, 20}) {
+		t.Errorf("완료 페이지 %v, 완료 게시글 %v", got.CompletedPages, got.FinishedArticles)
+	}
+	if !got.isPageDone(3) || got.isPageDone(2) || !got.isArticleDone(10) {
+		t.Error("불러온 완료 표시가 저장한 것과 다릅니다")
+	}
+	if got.OutputOffsets["articles.csv"] != 42 {
+		t.Errorf("OutputOffsets = %v", got.OutputOffsets)
+	}
+
+	// 출력에 실제로 기록된 게시글로 완료 목록 교체
+	got.resetArticles(map[string]bool{"10": true, "30": true, "x": true})
+	if got.isArticleDone(20) || !got.isArticleDone(30) || got.articleCount() != 2 {
+		t.Errorf("resetArticles() 후 완료 게시글 %d개", got.articleCount())
+	}
+
+	if err := got.remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(checkpointPath(dir, "1", "2")); !os.IsNotExist(err) {
+		t.Errorf("체크포인트가 남아 있습니다: %v", err)
+	}
+	if err := got.remove(); err != nil {
+		t.Errorf("없는 체크포인트 remove() error = %v", err)
+	}
+}
+
+func TestCrawlBoardResumeFailedArticles(t *testing.T) {
+	board := &testBoard{top: 9, fail: map[int]bool{5: true}}
+	c := newTestCrawler(t, board.handle)
+	outputDir := c.outputDir
+	opts := BoardOptions{PageSize: testBoardPageSize}
+
+	if _, err := c.CrawlBoard(context.Background(), "1", "2", opts); err != nil {
+		t.Fatal(err)
+	}
+	// 상세 정보를 가져오지 못한 게시글이 있으면 체크포인트를 남김
+	cp, err := loadCheckpoint(outputDir, "1", "2", output.FormatJSONL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cp.resumed {
+		t.Fatal("실패한 게시글이 있는데 체크포인트를 지웠습니다")
+	}
+	if !reflect.DeepEqual(cp.CompletedPages, []int{1, 3}) {
+		t.Errorf("완료 페이지 = %v, want [1 3]", cp.CompletedPages)
+	}
+	if want := []int{1, 2, 3, 4, 6, 7, 8, 9}; !reflect.DeepEqual(cp.FinishedArticles, want) {
+		t.Errorf("완료 게시글 = %v, want %v", cp.FinishedArticles, want)
+	}
+
+	// 재개하면 실패한 게시글만 다시 가져오고 체크포인트를 지움
+	board.fail = nil
+	board.fetched = nil
+	opts.Resume = true
+	if _, err := c.CrawlBoard(context.Background(), "1", "2", opts); err != nil {
+		t.Fatal(err)
+	}
+	sort.Ints(board.fetched)
+	if !reflect.DeepEqual(board.fetched, []int{5}) {
+		t.Errorf("재개 후 상세 정보를 요청한 게시글 = %v, want [5]", board.fetched)
+	}
+	if _, err := os.Stat(checkpointPath(outputDir, "1", "2")); !os.IsNotExist(err) {
+		t.Errorf("크롤링을 마쳤는데 체크포인트가 남아 있습니다: %v", err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(outputDir, "cafe_1_board_2_*_full.jsonl"))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("전체 결과 파일 = %v, %v", files, err)
+	}
+	ids, err := output.NewJSONLSink(files[0]).ArticleIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 9 {
+		t.Errorf("전체 결과 게시글 %d개, want 9개", len(ids))
+	}
+}
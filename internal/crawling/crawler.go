@@ -0,0 +1,212 @@
+package crawling
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+	"time"
+
+	"naverCafeCrawler/internal/media"
+	"naverCafeCrawler/internal/output"
+	"naverCafeCrawler/internal/ratelimit"
+	"naverCafeCrawler/internal/session"
+)
+
+// 기본 요청 속도: 모든 고루틴을 합쳐 2초에 1회 + 최대 1초 지터
+const (
+	DefaultRequestsPerSecond = 0.5
+	DefaultJitter            = 1 * time.Second
+)
+
+const (
+	defaultCafeAPIBaseURL = "https://apis.naver.com/cafe-web"
+	defaultBlogBaseURL    = "https://blog.naver.com"
+	defaultUserAgent      = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/136.0.0.0 Safari/537.36"
+)
+
+// Crawler 는 네이버 카페/블로그 크롤링에 필요한 HTTP 설정을 보관합니다.
+type Crawler struct {
+	client         *http.Client
+	cafeAPIBaseURL string
+	blogBaseURL    string
+	headers        http.Header
+	cookie         string
+	limiter        *ratelimit.Limiter
+	outputDir      string
+	blogOutputDir  string
+	debugDir       string // JSON 대신 돌아온 HTML 페이지를 저장할 디렉토리 (WithDebugDir)
+	retry          RetryPolicy
+	outputFormat   output.Format
+	concurrency    int
+	media          *media.Store
+	session        *session.Session // 로그인 세션 (WithSession)
+	probeURL       string           // 로그인 상태 확인 요청 주소
+	// 세션 풀 (WithSessionPool)과 세션별 클라이언트/속도 제한기
+	pool   *session.Pool
+	routes map[*session.Account]route
+}
+
+// Option 은 Crawler 설정을 변경하는 함수입니다.
+type Option func(*Crawler)
+
+// New 는 기본 설정에 옵션을 적용한 Crawler 를 생성합니다.
+func New(opts ...Option) *Crawler {
+	c := &Crawler{
+		client: &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					MinVersion: tls.VersionTLS12,
+				},
+			},
+			Timeout: 10 * time.Second,
+		},
+		cafeAPIBaseURL: defaultCafeAPIBaseURL,
+		blogBaseURL:    defaultBlogBaseURL,
+		headers:        http.Header{},
+		limiter:        ratelimit.New(DefaultRequestsPerSecond, 1, ratelimit.WithJitter(DefaultJitter)),
+		outputDir:      "output",
+		blogOutputDir:  "output_blog",
+		retry:          DefaultRetryPolicy,
+		concurrency:    3,
+		probeURL:       defaultSessionProbeURL,
+	}
+	c.headers.Set("User-Agent", defaultUserAgent)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.applySession()
+	return c
+}
+
+// WithHTTPClient 는 요청에 사용할 HTTP 클라이언트를 지정합니다.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Crawler) {
+		c.client = client
+	}
+}
+
+// WithCafeAPIBaseURL 은 카페 API 주소(기본값 https://apis.naver.com/cafe-web)를 지정합니다.
+func WithCafeAPIBaseURL(baseURL string) Option {
+	return func(c *Crawler) {
+		c.cafeAPIBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithBlogBaseURL 은 블로그 주소(기본값 https://blog.naver.com)를 지정합니다.
+func WithBlogBaseURL(baseURL string) Option {
+	return func(c *Crawler) {
+		c.blogBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHeader 는 모든 요청에 추가할 헤더를 지정합니다.
+func WithHeader(key, value string) Option {
+	return func(c *Crawler) {
+		c.headers.Set(key, value)
+	}
+}
+
+// WithCookie 는 카페 API 요청에 사용할 로그인 쿠키를 지정합니다.
+func WithCookie(cookie string) Option {
+	return func(c *Crawler) {
+		c.cookie = cookie
+	}
+}
+
+// WithRateLimiter 는 요청 속도 제한기를 지정합니다.
+// 여러 Crawler 에 같은 Limiter 를 넘기면 요청 예산을 공유합니다.
+func WithRateLimiter(l *ratelimit.Limiter) Option {
+	return func(c *Crawler) {
+		c.limiter = l
+	}
+}
+
+// WithRateLimit 은 이 Crawler 전용 속도 제한(초당 요청 수, 버스트)을 지정합니다.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Crawler) {
+		c.limiter = ratelimit.New(rps, burst, ratelimit.WithJitter(DefaultJitter))
+	}
+}
+
+// WithOutputDir 은 카페 크롤링 결과 저장 디렉토리를 지정합니다.
+func WithOutputDir(dir string) Option {
+	return func(c *Crawler) {
+		c.outputDir = dir
+	}
+}
+
+// WithConcurrency 는 동시에 처리할 목록 페이지 수(기본값 3)를 지정합니다.
+// 실제 요청 속도는 속도 제한기가 결정합니다.
+func WithConcurrency(n int) Option {
+	return func(c *Crawler) {
+		if n > 0 {
+			c.concurrency = n
+		}
+	}
+}
+
+// WithMediaDir 은 본문과 댓글의 이미지/동영상/첨부 파일을 dir 에 내려받도록 합니다.
+// 지정하지 않으면 미디어를 내려받지 않습니다.
+func WithMediaDir(dir string) Option {
+	return func(c *Crawler) {
+		if dir != "" {
+			c.media = media.NewStore(dir)
+		}
+	}
+}
+
+// WithOutputFormat 은 전체 결과 출력 형식을 지정합니다.
+// 지정하지 않으면 카페는 JSONL, 블로그는 JSON 배열로 기록합니다.
+func WithOutputFormat(format output.Format) Option {
+	return func(c *Crawler) {
+		c.outputFormat = format
+	}
+}
+
+// WithBlogOutputDir 은 블로그 크롤링 결과 저장 디렉토리를 지정합니다.
+func WithBlogOutputDir(dir string) Option {
+	return func(c *Crawler) {
+		c.blogOutputDir = dir
+	}
+}
+
+// 지정된 출력 형식, 없으면 def
+func (c *Crawler) formatOr(def output.Format) output.Format {
+	if c.outputFormat != "" {
+		return c.outputFormat
+	}
+	return def
+}
+
+// ctx 취소를 존중하는 time.Sleep
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// 공통 헤더를 적용한 GET 요청 생성
+func (c *Crawler) newRequest(ctx context.Context, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range c.headers {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
+	return req, nil
+}
+
+// 공통 헤더를 적용해 GET 요청 수행
+func (c *Crawler) get(ctx context.Context, url string) (*http.Response, error) {
+	return c.doRequest(ctx, url, nil)
+}
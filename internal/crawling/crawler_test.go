@@ -0,0 +1,39 @@
+package crawling
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"naverCafeCrawler/internal/ratelimit"
+)
+
+// 요청을 보내지 않고 handler 가 돌려준 상태 코드와 본문으로 응답하는 Transport
+// Content-Type 은 본문이 JSON 이면 application/json, 아니면 본문으로 판단합니다.
+type stubTransport func(r *http.Request) (int, string)
+
+func (f stubTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	status, body := f(r)
+	contentType := "application/json;charset=UTF-8"
+	if !strings.HasPrefix(body, "{") && !strings.HasPrefix(body, "[") {
+		contentType = http.DetectContentType([]byte(body))
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    r,
+	}, nil
+}
+
+// stubTransport 로 응답하고 속도 제한, 재시도 없이 임시 디렉토리에 기록하는 Crawler
+func newTestCrawler(t *testing.T, handler func(r *http.Request) (int, string), opts ...Option) *Crawler {
+	t.Helper()
+	return New(append([]Option{
+		WithHTTPClient(&http.Client{Transport: stubTransport(handler)}),
+		WithRateLimiter(ratelimit.New(0, 1)),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+		WithOutputDir(t.TempDir()),
+	}, opts...)...)
+}
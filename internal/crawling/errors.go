@@ -0,0 +1,166 @@
+package crawling
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"naverCafeCrawler/internal/session"
+)
+
+// 요청 실패 원인. 크롤러가 반환하는 오류에 errors.Is 로 확인할 수 있습니다.
+var (
+	// ErrSessionExpired 는 로그인 쿠키가 없거나 만료된 경우입니다. 쿠키를 새로 발급해야 합니다.
+	ErrSessionExpired = errors.New("로그인 세션 만료")
+	// ErrRateLimited 는 재시도 후에도 요청 제한(429 등)에 걸린 경우입니다.
+	ErrRateLimited = errors.New("요청 제한 초과")
+	// ErrArticleDeleted 는 삭제되었거나 존재하지 않는 게시글입니다.
+	ErrArticleDeleted = errors.New("삭제된 게시글")
+	// ErrMembersOnly 는 카페 멤버(또는 특정 등급)만 볼 수 있는 게시글/게시판입니다.
+	ErrMembersOnly = errors.New("멤버 전용")
+	// ErrBoardNotFound 는 존재하지 않는 게시판(메뉴)입니다.
+	ErrBoardNotFound = errors.New("게시판 없음")
+	// ErrBlockedPage 는 비정상 접근 차단, 보호 조치 등으로 막힌 경우입니다.
+	ErrBlockedPage = errors.New("접근 차단")
+	// ErrCaptcha 는 API 대신 자동 입력 방지(캡차) 페이지가 돌아온 경우입니다. 브라우저에서 캡차를 풀어야 합니다.
+	ErrCaptcha = errors.New("캡차 요구")
+	// ErrMaintenance 는 API 대신 서비스 점검 안내 페이지가 돌아온 경우입니다.
+	ErrMaintenance = errors.New("서비스 점검 중")
+	// ErrUnexpectedResponse 는 JSON 을 기대한 요청에 원인을 알 수 없는 HTML 페이지 등이 돌아온 경우입니다.
+	ErrUnexpectedResponse = errors.New("알 수 없는 페이지")
+)
+
+// 크롤링을 계속해도 같은 오류가 반복되는 경우 (세션 만료, 접근 차단, 캡차, 세션 풀 소진)
+// 이런 오류가 나면 나머지 게시글을 요청하지 않고 크롤링을 중단합니다.
+// 재시도 후에도 남은 요청 제한이나 점검은 해당 페이지/게시글만 실패로 기록하고 계속 진행합니다.
+func isFatal(err error) bool {
+	return errors.Is(err, ErrSessionExpired) || errors.Is(err, ErrBlockedPage) ||
+		errors.Is(err, ErrCaptcha) || errors.Is(err, session.ErrNoAccount)
+}
+
+// 네이버 API 오류 코드별 원인. 메시지 문구보다 먼저 확인합니다.
+var errorCodes = map[string]error{
+	"0004": ErrArticleDeleted, // 삭제되었거나 존재하지 않는 게시글
+	"4002": ErrMembersOnly,    // 권한 없음
+}
+
+// 오류 메시지에 포함된 문구로 원인 판단 (순서대로 먼저 맞는 것)
+// 멤버 전용 안내에도 "로그인", "가입" 같은 문구가 함께 나오므로 게시판, 게시글, 멤버 전용을 세션 만료보다 먼저 확인합니다.
+var errorKeywords = []struct {
+	keywords []string
+	err      error
+}{
+	{[]string{"존재하지 않는 게시판", "없는 게시판", "존재하지 않는 메뉴", "없는 메뉴"}, ErrBoardNotFound},
+	{[]string{"삭제", "존재하지 않는 게시글", "없는 게시글", "찾을 수 없는 게시글"}, ErrArticleDeleted},
+	{[]string{"멤버", "회원", "가입", "등급", "권한"}, ErrMembersOnly},
+	{[]string{"로그인", "login", "세션", "인증이 필요"}, ErrSessionExpired},
+	{[]string{"차단", "비정상", "보호조치", "보호 조치", "이용이 제한"}, ErrBlockedPage},
+	{[]string{"요청이 많", "too many"}, ErrRateLimited},
+}
+
+// classify 는 HTTP 상태 코드와 네이버 오류 코드, 오류 메시지, 요청 주소로 오류 원인을 판단합니다.
+// 오류 코드, 메시지 문구, 상태 코드 순서로 확인하며 알 수 없으면 nil 을 반환합니다.
+func classify(statusCode int, url, code, message string) error {
+	if err, ok := errorCodes[code]; ok {
+		return err
+	}
+	lower := strings.ToLower(message)
+	for _, k := range errorKeywords {
+		for _, keyword := range k.keywords {
+			if strings.Contains(lower, keyword) {
+				return k.err
+			}
+		}
+	}
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return ErrSessionExpired
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusForbidden:
+		if strings.Contains(url, "/cafes/") {
+			return ErrMembersOnly
+		}
+		return ErrBlockedPage
+	case http.StatusNotFound, http.StatusGone:
+		switch {
+		case strings.Contains(url, "/menus/"):
+			return ErrBoardNotFound
+		case strings.Contains(url, "/articles/"):
+			return ErrArticleDeleted
+		}
+	}
+	return nil
+}
+
+// 오류 응답 본문에서 읽을 최대 크기
+const maxErrorBody = 64 << 10
+
+// 네이버 API 오류 본문에서 오류 코드와 메시지 찾기
+// API 마다 형식이 달라 ({"result":{"errorCode","reason"}}, {"message":{"error":{"code","msg"}}} 등)
+// 알려진 키를 재귀적으로 찾습니다.
+func parseErrorBody(body []byte) (code, message string) {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", ""
+	}
+	var walk func(v interface{}, depth int)
+	walk = func(v interface{}, depth int) {
+		m, ok := v.(map[string]interface{})
+		if !ok || depth > 4 {
+			return
+		}
+		for _, key := range []string{"errorCode", "code"} {
+			if s, ok := m[key].(string); ok && s != "" && code == "" {
+				code = s
+			}
+		}
+		for _, key := range []string{"reason", "errorMessage", "msg", "message"} {
+			if s, ok := m[key].(string); ok && s != "" && message == "" {
+				message = s
+			}
+		}
+		for _, child := range m {
+			walk(child, depth+1)
+		}
+	}
+	walk(v, 0)
+	return code, message
+}
+
+// 네이버 API 가 본문에 담아 돌려준 오류 (HTTP 상태는 200 일 수 있음)
+func apiError(statusCode int, url, code, message string) *StatusError {
+	return &StatusError{
+		StatusCode: statusCode,
+		URL:        url,
+		Code:       code,
+		Message:    message,
+		Err:        classify(statusCode, url, code, message),
+	}
+}
+
+// 오류 설명 (원인, 상태 코드, 네이버 메시지, 저장한 응답 본문)
+func describeStatus(e *StatusError) string {
+	var b strings.Builder
+	if e.Err != nil {
+		b.WriteString(e.Err.Error() + ", ")
+	}
+	switch {
+	case e.ContentType != "" && e.StatusCode == http.StatusOK:
+		fmt.Fprintf(&b, "JSON 대신 %s 응답", e.ContentType)
+	case e.StatusCode == http.StatusOK:
+		b.WriteString("API 오류")
+	default:
+		fmt.Fprintf(&b, "HTTP 오류: %d", e.StatusCode)
+	}
+	if e.Code != "" || e.Message != "" {
+		fmt.Fprintf(&b, " (%s)", strings.TrimSpace(e.Code+" "+e.Message))
+	}
+	if e.BodyFile != "" {
+		fmt.Fprintf(&b, ", 응답 본문: %s", e.BodyFile)
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+package crawling
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"naverCafeCrawler/internal/session"
+)
+
+func TestClassify(t *testing.T) {
+	const (
+		cafeURL    = "https://apis.naver.com/cafe-web/cafe2/CafeGateInfo.json?cafeId=1"
+		memberURL  = "https://apis.naver.com/cafe-web/cafe-cafemain-api/v1.0/cafes/1/members"
+		menuURL    = "https://apis.naver.com/cafe-web/cafe-boardlist-api/v1/cafes/1/menus/3/articles?page=1"
+		articleURL = "https://apis.naver.com/cafe-web/cafe-articleapi/v2.1/cafes/1/articles/42"
+	)
+	tests := []struct {
+		name    string
+		status  int
+		url     string
+		code    string
+		message string
+		want    error
+	}{
+		{"로그인 문구", http.StatusOK, cafeURL, "", "로그인이 필요합니다.", ErrSessionExpired},
+		{"대소문자 무시", http.StatusOK, cafeURL, "", "Login Required", ErrSessionExpired},
+		{"삭제된 게시글", http.StatusOK, articleURL, "", "삭제되었거나 존재하지 않는 게시글입니다.", ErrArticleDeleted},
+		{"없는 게시판", http.StatusOK, menuURL, "", "존재하지 않는 게시판입니다.", ErrBoardNotFound},
+		{"멤버 전용 문구", http.StatusOK, articleURL, "", "카페 멤버만 볼 수 있습니다.", ErrMembersOnly},
+		{"차단 문구", http.StatusOK, cafeURL, "", "비정상적인 접근이 감지되었습니다.", ErrBlockedPage},
+		{"요청 제한 문구", http.StatusOK, cafeURL, "", "Too many requests", ErrRateLimited},
+		{"잠시 후 안내는 요청 제한이 아님", http.StatusOK, cafeURL, "", "잠시 후 다시 시도해 주세요.", nil},
+		{"로그인이 함께 나오는 멤버 전용 안내", http.StatusOK, articleURL, "", "카페 멤버만 볼 수 있습니다. 로그인 후 카페에 가입해 주세요.", ErrMembersOnly},
+		{"로그인이 함께 나오는 등급 안내", http.StatusForbidden, articleURL, "", "로그인한 회원 중 우수 등급 이상만 읽을 수 있습니다.", ErrMembersOnly},
+		{"삭제 안내의 멤버 문구", http.StatusOK, articleURL, "", "작성자가 삭제한 게시글입니다. 멤버에게 문의하세요.", ErrArticleDeleted},
+		{"없는 게시판의 권한 문구", http.StatusOK, menuURL, "", "존재하지 않는 게시판이거나 권한이 없습니다.", ErrBoardNotFound},
+		{"오류 코드가 문구보다 우선", http.StatusOK, articleURL, "0004", "로그인 정보를 확인해 주세요.", ErrArticleDeleted},
+		{"권한 오류 코드", http.StatusOK, menuURL, "4002", "", ErrMembersOnly},
+		{"모르는 오류 코드는 문구로 판단", http.StatusOK, cafeURL, "9999", "로그인이 필요합니다.", ErrSessionExpired},
+		{"문구가 상태 코드보다 우선", http.StatusForbidden, memberURL, "", "로그인 후 이용해 주세요", ErrSessionExpired},
+		{"401", http.StatusUnauthorized, cafeURL, "", "", ErrSessionExpired},
+		{"429", http.StatusTooManyRequests, cafeURL, "", "", ErrRateLimited},
+		{"카페 API 403", http.StatusForbidden, memberURL, "", "", ErrMembersOnly},
+		{"다른 403", http.StatusForbidden, cafeURL, "", "", ErrBlockedPage},
+		{"게시판 404", http.StatusNotFound, menuURL, "", "", ErrBoardNotFound},
+		{"게시글 410", http.StatusGone, articleURL, "", "", ErrArticleDeleted},
+		{"다른 404", http.StatusNotFound, cafeURL, "", "", nil},
+		{"알 수 없는 500", http.StatusInternalServerError, cafeURL, "", "internal error", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classify(tt.status, tt.url, tt.code, tt.message)
+			if !errors.Is(got, tt.want) {
+				t.Errorf("classify(%d, %q, %q, %q) = %v, want %v", tt.status, tt.url, tt.code, tt.message, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{ErrSessionExpired, true},
+		{ErrBlockedPage, true},
+		{ErrCaptcha, true},
+		{session.ErrNoAccount, true},
+		{&StatusError{StatusCode: http.StatusOK, Err: ErrCaptcha}, true},
+		{ErrRateLimited, false},
+		{ErrMaintenance, false},
+		{ErrMembersOnly, false},
+		{ErrArticleDeleted, false},
+		{ErrUnexpectedResponse, false},
+		{errors.New("internal error"), false},
+	}
+	for _, tt := range tests {
+		if got := isFatal(fmt.Errorf("게시글 42: %w", tt.err)); got != tt.want {
+			t.Errorf("isFatal(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrorBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantCode    string
+		wantMessage string
+	}{
+		{"result 안의 errorCode, reason", `{"result":{"errorCode":"0004","reason":"삭제된 게시글입니다."}}`, "0004", "삭제된 게시글입니다."},
+		{"message.error 안의 code, msg", `{"message":{"status":"500","error":{"code":"4002","msg":"권한이 없습니다."}}}`, "4002", "권한이 없습니다."},
+		{"최상위 errorMessage", `{"code":"","errorMessage":"잠시 후 다시 시도해 주세요."}`, "", "잠시 후 다시 시도해 주세요."},
+		{"reason 이 message 보다 우선", `{"message":"일반 메시지","reason":"자세한 이유"}`, "", "자세한 이유"},
+		{"바깥 값이 안쪽보다 우선", `{"code":"outer","result":{"code":"inner"}}`, "outer", ""},
+		{"문자열이 아닌 값 무시", `{"code":404,"message":{"msg":"없음"}}`, "", "없음"},
+		{"깊이 4까지", `{"a":{"b":{"c":{"d":{"code":"deep"}}}}}`, "deep", ""},
+		{"깊이 5는 무시", `{"a":{"b":{"c":{"d":{"e":{"code":"too-deep"}}}}}}`, "", ""},
+		{"JSON 배열", `[{"code":"x"}]`, "", ""},
+		{"JSON 아님", `<html><title>오류</title></html>`, "", ""},
+		{"빈 본문", ``, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, message := parseErrorBody([]byte(tt.body))
+			if code != tt.wantCode || message != tt.wantMessage {
+				t.Errorf("parseErrorBody(%s) = (%q, %q), want (%q, %q)", tt.body, code, message, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package crawling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"naverCafeCrawler/internal/output"
+)
+
+// 증분 크롤링 상태 (cafeId, boardID 별로 하나)
+type boardState struct {
+	CafeID          string        `json:"cafe_id"`
+	BoardID         string        `json:"board_id"`
+	LastArticleID   int           `json:"last_article_id"`  // 수집을 마친 가장 큰 게시글 ID
+	LastWriteTime   int64         `json:"last_write_time"`  // 수집을 마친 가장 최근 작성 시각 (밀리초)
+	Format          output.Format `json:"format,omitempty"` // 아카이브 출력 형식
+	ArchiveFilename string        `json:"archive_filename"` // 누적 결과 파일 경로
+	// 아카이브 파일별로 지난 실행이 기록을 마친 위치 (CSV 처럼 줄 단위로 복구할 수 없는 형식만)
+	ArchiveOffsets map[string]int64 `json:"archive_offsets,omitempty"`
+	LastRunAt      string           `json:"last_run_at"`
+}
+
+func boardStatePath(outputDir, cafeId, boardID string) string {
+	return filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s.state.json", cafeId, boardID))
+}
+
+// 아카이브 경로 (확장자 제외, 실제 파일명은 출력 형식에 따라 다름)
+func archiveBase(outputDir, cafeId, boardID string) string {
+	return filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_archive", cafeId, boardID))
+}
+
+func loadBoardState(outputDir, cafeId, boardID string) (*boardState, error) {
+	data, err := os.ReadFile(boardStatePath(outputDir, cafeId, boardID))
+	if errors.Is(err, os.ErrNotExist) {
+		return &boardState{CafeID: cafeId, BoardID: boardID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("증분 상태 읽기 실패: %v", err)
+	}
+
+	state := &boardState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("증분 상태 파싱 실패: %v", err)
+	}
+	return state, nil
+}
+
+// 증분 크롤링
+// sortBy=TIME 목록을 1페이지부터 넘기다가 이미 수집한 게시글이 나오면 멈추고,
+// 새 게시글만 상세 정보를 가져와 출력 Sink(기본값 JSON)의 아카이브에 이어서 기록합니다.
+// 아카이브에 이미 있는 게시글은 다시 기록하지 않습니다. 반환값은 새로 수집한 게시글입니다 (StreamOnly 이면 nil).
+func (c *Crawler) crawlBoardIncremental(ctx context.Context, cafeId, boardID string, opts BoardOptions, pageSize int) ([]CafeArticle, error) {
+	outputDir := c.outputDir
+	state, err := loadBoardState(outputDir, cafeId, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	format := c.formatOr(output.FormatJSON)
+	base := archiveBase(outputDir, cafeId, boardID)
+	state.Format = format
+	state.ArchiveFilename = output.Path(format, base)
+	sink, err := output.New(format, base)
+	if err != nil {
+		return nil, err
+	}
+
+	// 지난 실행이 중간에 끊겨 기록을 마친 위치 뒤에 남은 불완전한 행 제거
+	positioner, _ := sink.(output.Positioner)
+	if positioner != nil && state.ArchiveOffsets != nil {
+		if err := positioner.TruncateTo(state.ArchiveOffsets); err != nil {
+			return nil, err
+		}
+	}
+
+	// 이전 실행에서 아카이브에 기록했지만 기준점을 옮기지 못한 게시글은 건너뜀
+	archived := make(map[string]bool)
+	if resumable, ok := sink.(output.Resumable); ok {
+		if archived, err = resumable.ArticleIDs(); err != nil {
+			return nil, fmt.Errorf("아카이브 읽기 실패: %v", err)
+		}
+	}
+
+	if state.LastArticleID > 0 {
+		log.Printf("🔄 증분 크롤링 시작 (마지막 수집 게시글 %d, %s)",
+			state.LastArticleID, formatTimestamp(state.LastWriteTime))
+	} else {
+		log.Printf("🔄 증분 크롤링 상태가 없어 처음부터 수집합니다.")
+	}
+
+	var (
+		newPosts      []CafeArticle
+		archivedPosts []CafeArticle // 아카이브에 이미 있어 건너뛴 게시글
+		complete      bool          // 이미 수집한 게시글이나 마지막 페이지까지 목록을 넘겼는지
+	)
+	// 아카이브에 이미 있는 게시글만 있는 페이지는 최대 페이지 수에 세지 않으므로
+	// 최대 페이지 수에서 멈춘 지난 실행의 나머지 게시글을 이어서 수집합니다.
+	for page, counted := 1, 0; ; page++ {
+		log.Printf("📥 %d페이지 로딩 중...", page)
+		posts, lastPage, err := c.getPostList(ctx, cafeId, boardID, page, pageSize)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("페이지 %d 크롤링 실패: %w", page, err)
+		}
+
+		reachedSeen := false
+		fresh := 0
+		for _, post := range posts {
+			if post.ID <= state.LastArticleID {
+				reachedSeen = true
+				continue
+			}
+			if archived[strconv.Itoa(post.ID)] {
+				archivedPosts = append(archivedPosts, post)
+				continue
+			}
+			newPosts = append(newPosts, post)
+			fresh++
+		}
+		log.Printf("✅ %d페이지 로드 완료 (새 게시글 누적 %d개)", page, len(newPosts))
+
+		if reachedSeen || len(posts) == 0 || page >= lastPage {
+			complete = true
+			break
+		}
+		if fresh > 0 {
+			counted++
+		}
+		if opts.MaxPages > 0 && counted >= opts.MaxPages {
+			break
+		}
+	}
+
+	// 최대 페이지 수에서 멈췄으면 그보다 오래된 새 게시글이 남아 있을 수 있으므로 기준점을 옮기지 않음
+	if !complete {
+		log.Printf("⚠️ 최대 %d페이지에서 멈춰 이미 수집한 게시글까지 닿지 못했습니다. 기준점(%d)을 유지하고 다음 실행에서 나머지를 이어서 수집합니다.",
+			opts.MaxPages, state.LastArticleID)
+	}
+
+	if len(newPosts) == 0 {
+		log.Printf("✅ 새 게시글이 없습니다.")
+		if complete {
+			state.advance(archivedPosts, nil)
+		}
+		state.LastRunAt = time.Now().Format("2006-01-02 15:04:05")
+		if err := output.SaveJSON(state, boardStatePath(outputDir, cafeId, boardID)); err != nil {
+			log.Printf("⚠️ 증분 상태 저장 실패: %v", err)
+		}
+		return nil, nil
+	}
+
+	if err := sink.Open(); err != nil {
+		return nil, fmt.Errorf("아카이브 열기 실패: %v", err)
+	}
+	emit := func(post CafeArticle) error {
+		article, comments := post.sinkRecords(cafeId, boardID)
+		return output.WriteArticleWithComments(sink, article, comments)
+	}
+
+	log.Printf("📝 새 게시글 %d개 상세 정보 수집 중...", len(newPosts))
+	newPosts, failed, detailErr := c.fillArticleDetails(ctx, cafeId, "새", newPosts, emit)
+	if err := sink.Close(); err != nil {
+		return nil, fmt.Errorf("아카이브 저장 실패: %v", err)
+	}
+	if positioner != nil {
+		state.ArchiveOffsets = positioner.Offsets()
+	}
+	log.Printf("💾 새 게시글 %d개를 %s 아카이브에 기록했습니다.", len(newPosts), state.ArchiveFilename)
+
+	// 중단된 경우에는 아직 처리하지 못한 (더 오래된) 게시글이 남아 있으므로 기준점을 옮기지 않음
+	if complete && detailErr == nil {
+		state.advance(append(archivedPosts, newPosts...), failed)
+	}
+
+	state.LastRunAt = time.Now().Format("2006-01-02 15:04:05")
+	if err := output.SaveJSON(state, boardStatePath(outputDir, cafeId, boardID)); err != nil {
+		log.Printf("⚠️ 증분 상태 저장 실패: %v", err)
+	}
+
+	if opts.StreamOnly {
+		newPosts = nil
+	}
+	if detailErr != nil {
+		return newPosts, detailErr
+	}
+
+	log.Printf("🎉 증분 크롤링 완료! 새 게시글 %d개 수집", len(newPosts))
+	return newPosts, nil
+}
+
+// 수집을 마친 게시글까지 기준점 옮기기
+// 상세 정보를 가져오지 못한 게시글은 다음 실행에서 다시 수집하도록 기준점을 그 이전으로 제한합니다.
+func (s *boardState) advance(posts []CafeArticle, failed map[int]bool) {
+	minFailed := 0
+	for id := range failed {
+		if minFailed == 0 || id < minFailed {
+			minFailed = id
+		}
+	}
+	for _, post := range posts {
+		if failed[post.ID] || (minFailed > 0 && post.ID >= minFailed) {
+			continue
+		}
+		if post.ID > s.LastArticleID {
+			s.LastArticleID = post.ID
+		}
+		if post.WriteTime > s.LastWriteTime {
+			s.LastWriteTime = post.WriteTime
+		}
+	}
+}
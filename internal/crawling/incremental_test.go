@@ -0,0 +1,149 @@
+package crawling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"naverCafeCrawler/internal/output"
+)
+
+var (
+	articleListRe   = regexp.MustCompile(`/cafe-boardlist-api/v1/cafes/\d+/menus/\d+/articles$`)
+	articleDetailRe = regexp.MustCompile(`/cafe-articleapi/v3/cafes/\d+/articles/(\d+)$`)
+)
+
+// 게시글 ID top 부터 1 까지 한 페이지에 3개씩 최신순으로 보여주는 게시판
+// 상세 정보를 요청한 게시글 ID 를 fetched 에 기록하고, fail 에 있는 게시글은 500 으로 응답합니다.
+type testBoard struct {
+	top     int
+	fail    map[int]bool
+	mu      sync.Mutex
+	fetched []int
+}
+
+const testBoardPageSize = 3
+
+func (b *testBoard) handle(r *http.Request) (int, string) {
+	if articleListRe.MatchString(r.URL.Path) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		var items []string
+		for i := 0; i < testBoardPageSize; i++ {
+			id := b.top - (page-1)*testBoardPageSize - i
+			if id <= 0 {
+				break
+			}
+			items = append(items, fmt.Sprintf(`{"type":"ARTICLE","item":{"articleId":%d,"subject":"제목 %d","writeDateTimestamp":%d,"writerInfo":{"nickName":"작성자"}}}`,
+				id, id, int64(id)*1000))
+		}
+		lastPage := (b.top + testBoardPageSize - 1) / testBoardPageSize
+		return http.StatusOK, fmt.Sprintf(`{"result":{"articleList":[%s],"pageInfo":{"lastNavigationPageNumber":%d}}}`,
+			strings.Join(items, ","), lastPage)
+	}
+	if m := articleDetailRe.FindStringSubmatch(r.URL.Path); m != nil {
+		id, _ := strconv.Atoi(m[1])
+		b.mu.Lock()
+		b.fetched = append(b.fetched, id)
+		b.mu.Unlock()
+		if b.fail[id] {
+			return http.StatusInternalServerError, `{}`
+		}
+		return http.StatusOK, fmt.Sprintf(`{"result":{"article":{"id":%d,"subject":"제목 %d","contentHtml":"<p>본문 %d</p>","commentCount":0},"comments":{"items":[]}}}`,
+			id, id, id)
+	}
+	return http.StatusNotFound, `{}`
+}
+
+// from 부터 to 까지 1씩 줄어드는 게시글 ID
+func articleIDs(from, to int) []int {
+	var ids []int
+	for id := from; id >= to; id-- {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestBoardStateAdvance(t *testing.T) {
+	posts := []CafeArticle{{ID: 5, WriteTime: 500}, {ID: 4, WriteTime: 400}, {ID: 3, WriteTime: 300}}
+	tests := []struct {
+		name      string
+		last      int
+		failed    map[int]bool
+		wantID    int
+		wantWrite int64
+	}{
+		{"모두 수집", 0, nil, 5, 500},
+		{"가장 새 게시글 실패", 0, map[int]bool{5: true}, 4, 400},
+		{"중간 게시글 실패", 0, map[int]bool{4: true}, 3, 300},
+		{"모두 실패", 0, map[int]bool{3: true, 4: true, 5: true}, 0, 0},
+		{"목록에 없는 게시글 실패", 0, map[int]bool{2: true}, 0, 0},
+		{"기준점보다 오래된 게시글", 10, nil, 10, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &boardState{LastArticleID: tt.last}
+			s.advance(posts, tt.failed)
+			if s.LastArticleID != tt.wantID || s.LastWriteTime != tt.wantWrite {
+				t.Errorf("advance() = (%d, %d), want (%d, %d)", s.LastArticleID, s.LastWriteTime, tt.wantID, tt.wantWrite)
+			}
+		})
+	}
+}
+
+func TestCrawlBoardIncremental(t *testing.T) {
+	board := &testBoard{top: 30, fail: map[int]bool{}}
+	c := newTestCrawler(t, board.handle, WithOutputFormat(output.FormatJSONL))
+	outputDir := c.outputDir
+
+	steps := []struct {
+		name     string
+		top      int
+		maxPages int
+		fail     []int
+		want     []int // 상세 정보를 요청한 게시글
+		wantLast int   // 실행 후 기준점
+	}{
+		// 최대 페이지 수에서 멈추면 기준점을 옮기지 않고 다음 실행에서 이어서 수집
+		{"최대 페이지에서 멈춤", 30, 2, nil, articleIDs(30, 25), 0},
+		{"아카이브에 있는 페이지는 세지 않음", 30, 2, nil, articleIDs(24, 19), 0},
+		{"실패한 게시글 앞까지만 기준점 이동", 30, 0, []int{10}, articleIDs(18, 1), 9},
+		{"실패한 게시글 다시 수집", 30, 0, nil, []int{10}, 30},
+		{"새 게시글만 수집", 33, 2, nil, articleIDs(33, 31), 33},
+		{"새 게시글 없음", 33, 0, nil, nil, 33},
+	}
+	for _, step := range steps {
+		board.top = step.top
+		board.fetched = nil
+		board.fail = make(map[int]bool)
+		for _, id := range step.fail {
+			board.fail[id] = true
+		}
+		if _, err := c.CrawlBoard(context.Background(), "1", "2", BoardOptions{Incremental: true, MaxPages: step.maxPages}); err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if !reflect.DeepEqual(board.fetched, step.want) {
+			t.Errorf("%s: 상세 정보를 요청한 게시글 = %v, want %v", step.name, board.fetched, step.want)
+		}
+		state, err := loadBoardState(outputDir, "1", "2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state.LastArticleID != step.wantLast {
+			t.Errorf("%s: 기준점 = %d, want %d", step.name, state.LastArticleID, step.wantLast)
+		}
+	}
+
+	ids, err := output.NewJSONLSink(output.Path(output.FormatJSONL, archiveBase(outputDir, "1", "2"))).ArticleIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 33 {
+		t.Errorf("아카이브 게시글 %d개, want 33개", len(ids))
+	}
+}
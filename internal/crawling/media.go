@@ -0,0 +1,83 @@
+package crawling
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"strconv"
+
+	"naverCafeCrawler/internal/media"
+)
+
+// 미디어 요청 함수. 네이버 이미지 서버는 Referer 가 없으면 요청을 거부하기도 합니다.
+func (c *Crawler) mediaFetcher(referer string) media.FetchFunc {
+	return func(ctx context.Context, url string) (*http.Response, error) {
+		return c.doRequest(ctx, url, func(req *http.Request) error {
+			setDefaultHeader(req, "Referer", referer)
+			return nil
+		})
+	}
+}
+
+// 미디어 다운로드 결과 로그
+func logMedia(kind, id string, files []media.File) {
+	failed := 0
+	for _, f := range files {
+		if f.Error != "" {
+			failed++
+			log.Printf("⚠️ %s %s 미디어 다운로드 실패: %s (%s)", kind, id, f.URL, f.Error)
+		}
+	}
+	if len(files) > 0 {
+		log.Printf("🖼️ %s %s 미디어 %d개 저장 (실패 %d개)", kind, id, len(files)-failed, failed)
+	}
+}
+
+// 카페 게시글 본문과 댓글의 미디어를 내려받고 로컬 경로를 가리키도록 바꿈
+// 원래 주소는 Media 에 남습니다.
+func (c *Crawler) localizeCafeMedia(ctx context.Context, a *CafeArticle) error {
+	refs := media.Extract(a.ContentHTML)
+	for _, comment := range a.Comments {
+		refs = append(refs,
+			media.Ref{URL: comment.Image, Kind: media.KindImage},
+			media.Ref{URL: comment.Sticker, Kind: media.KindImage})
+	}
+	refs = media.Unique(refs)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	files, err := c.media.DownloadAll(ctx, c.mediaFetcher("https://cafe.naver.com"), refs)
+	if err != nil {
+		return err
+	}
+	a.Media = files
+	a.setContent(media.Rewrite(a.ContentHTML, files))
+	for i := range a.Comments {
+		if a.Comments[i].Image != "" {
+			a.Comments[i].Image = media.LocalPath(a.Comments[i].Image, files)
+		}
+		if a.Comments[i].Sticker != "" {
+			a.Comments[i].Sticker = media.LocalPath(a.Comments[i].Sticker, files)
+		}
+	}
+	logMedia("게시글", strconv.Itoa(a.ID), files)
+	return nil
+}
+
+// 블로그 게시글 본문의 미디어를 내려받고 ContentHTML 이 로컬 경로를 가리키도록 바꿈
+func (c *Crawler) localizeBlogMedia(ctx context.Context, post *BlogPost) error {
+	refs := media.Extract(post.ContentHTML)
+	if len(refs) == 0 {
+		return nil
+	}
+
+	files, err := c.media.DownloadAll(ctx, c.mediaFetcher(c.blogBaseURL), refs)
+	if err != nil {
+		return err
+	}
+	post.Media = files
+	post.ContentHTML = media.Rewrite(post.ContentHTML, files)
+	logMedia("블로그 게시글", post.ID, files)
+	return nil
+}
@@ -0,0 +1,56 @@
+package crawling
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalizeCafeMedia(t *testing.T) {
+	const png = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+	var referers []string
+	mediaDir := filepath.Join(t.TempDir(), "media")
+	c := newTestCrawler(t, func(r *http.Request) (int, string) {
+		referers = append(referers, r.Header.Get("Referer"))
+		if strings.HasSuffix(r.URL.Path, "/missing.png") {
+			return http.StatusNotFound, `{}`
+		}
+		return http.StatusOK, png + r.URL.Path
+	}, WithMediaDir(mediaDir))
+
+	a := CafeArticle{
+		ID: 42,
+		Comments: []CafeComment{
+			{ID: 1, Sticker: "https://storep-phinf.pstatic.net/sticker.png"},
+			{ID: 2, Image: "https://postfiles.pstatic.net/missing.png"},
+		},
+	}
+	a.setContent(`<p><img src="data:image/gif;base64,R0lG" data-lazy-src="https://postfiles.pstatic.net/photo.png?type=w800"></p>`)
+	if err := c.localizeCafeMedia(context.Background(), &a); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(a.Media) != 3 {
+		t.Fatalf("Media %d개, want 3개: %+v", len(a.Media), a.Media)
+	}
+	for _, referer := range referers {
+		if referer != "https://cafe.naver.com" {
+			t.Errorf("Referer = %q, want https://cafe.naver.com", referer)
+		}
+	}
+	photo := filepath.ToSlash(a.Media[0].Path)
+	if a.Media[0].URL != "https://postfiles.pstatic.net/photo.png?type=w800" || !strings.HasPrefix(photo, filepath.ToSlash(mediaDir)) {
+		t.Errorf("Media[0] = %+v", a.Media[0])
+	}
+	if !strings.Contains(a.ContentHTML, `data-lazy-src="`+photo+`"`) || !strings.Contains(a.ContentMarkdown, "]("+photo+")") {
+		t.Errorf("본문이 로컬 경로를 가리키지 않습니다:\n%s\n%s", a.ContentHTML, a.ContentMarkdown)
+	}
+	if got := a.Comments[0].Sticker; got != filepath.ToSlash(a.Media[1].Path) {
+		t.Errorf("댓글 스티커 = %s, want %s", got, a.Media[1].Path)
+	}
+	if got := a.Comments[1].Image; got != "https://postfiles.pstatic.net/missing.png" || a.Media[2].Error == "" {
+		t.Errorf("내려받지 못한 댓글 이미지 = %s, Media[2] = %+v", got, a.Media[2])
+	}
+}
@@ -0,0 +1,217 @@
+package crawling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// 메뉴 목록 API 응답 구조체
+type MenuListResponse struct {
+	Message struct {
+		Status string `json:"status"`
+		Error  struct {
+			Code string `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"error"`
+		Result struct {
+			Menus []struct {
+				MenuID    int    `json:"menuId"`
+				MenuName  string `json:"menuName"`
+				MenuType  string `json:"menuType"`
+				BoardType string `json:"boardType"`
+				LinkURL   string `json:"linkUrl"`
+				Indent    bool   `json:"indent"` // 바로 앞 폴더에 속한 메뉴
+				BadMenu   bool   `json:"badMenu"`
+			} `json:"menus"`
+		} `json:"result"`
+	} `json:"message"`
+}
+
+// 메뉴 종류
+const (
+	MenuBoard     = "board"     // 게시판 (menuType B)
+	MenuFolder    = "folder"    // 폴더 (menuType F)
+	MenuLink      = "link"      // 외부 링크 (menuType L)
+	MenuSeparator = "separator" // 구분선 (menuType S)
+	MenuOther     = "other"     // 메모, 출석부 등 게시글 목록이 없는 메뉴
+)
+
+// Menu 는 카페 메뉴(게시판) 하나입니다.
+type Menu struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Type       string `json:"type"`       // MenuBoard, MenuFolder 등
+	RawType    string `json:"menu_type"`  // API 의 menuType 코드
+	BoardType  string `json:"board_type"` // 목록 보기 형식 (L: 목록, I: 앨범 등)
+	FolderID   int    `json:"folder_id,omitempty"`
+	FolderName string `json:"folder_name,omitempty"`
+	// 게시판 크롤링(CrawlBoard)으로 글을 읽을 수 있는 메뉴인지 여부.
+	// 게시판이고 접근이 제한(badMenu)되지 않은 메뉴만 true 입니다.
+	// 회원 등급 때문에 글 목록을 볼 수 없는 게시판은 크롤링할 때 오류로 드러납니다.
+	Readable bool `json:"readable"`
+}
+
+func menuType(code string) string {
+	switch code {
+	case "B":
+		return MenuBoard
+	case "F":
+		return MenuFolder
+	case "L":
+		return MenuLink
+	case "S":
+		return MenuSeparator
+	default:
+		return MenuOther
+	}
+}
+
+// ListMenus 는 카페의 메뉴 목록을 카페에 표시되는 순서대로 반환합니다.
+// 폴더 아래 메뉴에는 FolderID, FolderName 이 채워집니다.
+func (c *Crawler) ListMenus(ctx context.Context, cafeId string) ([]Menu, error) {
+	url := fmt.Sprintf("%s/cafe2/SideMenuList?cafeId=%s", c.cafeAPIBaseURL, cafeId)
+
+	resp, err := c.getAPIResponse(ctx, cafeId, url)
+	if err != nil {
+		return nil, fmt.Errorf("메뉴 목록 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result MenuListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("메뉴 목록 파싱 실패: %v", err)
+	}
+	if status := result.Message.Status; status != "" && status != "200" {
+		statusCode, _ := strconv.Atoi(status)
+		return nil, fmt.Errorf("메뉴 목록 API 오류: %w", apiError(statusCode, url, result.Message.Error.Code, result.Message.Error.Msg))
+	}
+
+	var (
+		menus  []Menu
+		folder *Menu
+	)
+	for _, m := range result.Message.Result.Menus {
+		menu := Menu{
+			ID:        m.MenuID,
+			Name:      strings.TrimSpace(m.MenuName),
+			Type:      menuType(m.MenuType),
+			RawType:   m.MenuType,
+			BoardType: m.BoardType,
+		}
+		menu.Readable = menu.Type == MenuBoard && menu.ID > 0 && !m.BadMenu
+
+		switch {
+		case menu.Type == MenuFolder:
+			folder = &menu
+		case m.Indent && folder != nil:
+			menu.FolderID = folder.ID
+			menu.FolderName = folder.Name
+		default:
+			folder = nil
+		}
+		menus = append(menus, menu)
+	}
+	return menus, nil
+}
+
+// CafeOptions 는 카페 전체 크롤링 옵션입니다.
+type CafeOptions struct {
+	BoardOptions // 게시판마다 적용할 옵션
+
+	// Include 가 비어 있지 않으면 이 목록에 있는 게시판만 크롤링합니다.
+	// 메뉴 ID 또는 이름으로 지정하며, 폴더를 지정하면 폴더 아래 게시판 전체가 포함됩니다.
+	Include []string
+	// Exclude 에 있는 게시판(또는 폴더 아래 게시판)은 건너뜁니다.
+	Exclude []string
+}
+
+// BoardResult 는 카페 전체 크롤링에서 게시판 하나의 결과입니다.
+type BoardResult struct {
+	Menu     Menu
+	Articles int // 수집한 게시글 수 (StreamOnly 이면 0)
+	Err      error
+}
+
+// SelectMenus 는 menus 중 크롤링할 게시판을 고릅니다.
+// 읽을 수 있는 게시판 가운데 include(비어 있으면 전체)에 해당하고 exclude 에 해당하지 않는 것을 반환합니다.
+func SelectMenus(menus []Menu, include, exclude []string) []Menu {
+	var selected []Menu
+	for _, m := range menus {
+		if !m.Readable {
+			continue
+		}
+		if len(include) > 0 && !m.matches(include) {
+			continue
+		}
+		if m.matches(exclude) {
+			continue
+		}
+		selected = append(selected, m)
+	}
+	return selected
+}
+
+// 메뉴나 그 폴더의 ID 또는 이름이 names 중 하나와 같은지 확인
+func (m Menu) matches(names []string) bool {
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if name == strconv.Itoa(m.ID) || name == m.Name {
+			return true
+		}
+		if m.FolderID != 0 && (name == strconv.Itoa(m.FolderID) || name == m.FolderName) {
+			return true
+		}
+	}
+	return false
+}
+
+// CrawlCafe 는 카페의 읽을 수 있는 게시판 전체(또는 Include/Exclude 로 고른 게시판)를 차례로 크롤링합니다.
+// 게시판마다 CrawlBoard 와 같은 파일(cafe_{카페ID}_board_{게시판ID}_...)에 따로 기록하며,
+// 한 게시판이 실패해도 나머지 게시판을 계속 크롤링하지만,
+// 세션 만료(ErrSessionExpired)나 접근 차단(ErrBlockedPage) 등 다른 게시판도 실패할 오류면 중단합니다.
+// ctx 가 취소되면 그때까지의 결과를 ctx.Err() 와 함께 반환하고,
+// 실패한 게시판이 있으면 결과와 함께 오류를 반환합니다.
+func (c *Crawler) CrawlCafe(ctx context.Context, cafeId string, opts CafeOptions) ([]BoardResult, error) {
+	menus, err := c.ListMenus(ctx, cafeId)
+	if err != nil {
+		return nil, err
+	}
+	selected := SelectMenus(menus, opts.Include, opts.Exclude)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("크롤링할 게시판이 없습니다 (메뉴 %d개)", len(menus))
+	}
+	log.Printf("🗂️ 카페 %s 전체 크롤링: 게시판 %d개 (메뉴 %d개)", cafeId, len(selected), len(menus))
+
+	var (
+		results []BoardResult
+		failed  int
+	)
+	for i, menu := range selected {
+		log.Printf("📂 [%d/%d] 게시판 %d (%s) 크롤링 시작", i+1, len(selected), menu.ID, menu.Name)
+		posts, err := c.CrawlBoard(ctx, cafeId, strconv.Itoa(menu.ID), opts.BoardOptions)
+		results = append(results, BoardResult{Menu: menu, Articles: len(posts), Err: err})
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+		if err != nil && isFatal(err) {
+			log.Printf("⛔ 게시판 %d (%s) 크롤링 실패, 나머지 게시판은 크롤링하지 않습니다: %v", menu.ID, menu.Name, err)
+			return results, fmt.Errorf("게시판 %d 크롤링 중단: %w", menu.ID, err)
+		}
+		if err != nil {
+			failed++
+			log.Printf("⚠️ 게시판 %d (%s) 크롤링 실패: %v", menu.ID, menu.Name, err)
+		}
+	}
+
+	if failed > 0 {
+		return results, fmt.Errorf("게시판 %d개 중 %d개 크롤링 실패", len(selected), failed)
+	}
+	return results, nil
+}
@@ -1,11 +1,16 @@
 package crawling
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"naverCafeCrawler/internal/content"
+	"naverCafeCrawler/internal/media"
+	"naverCafeCrawler/internal/output"
 	"naverCafeCrawler/internal/utils"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -17,13 +22,19 @@ import (
 
 // BlogPost represents a blog post.
 type BlogPost struct {
-	ID          string        `json:"id"`
-	Title       string        `json:"title"`
-	Content     string        `json:"content"`
-	Writer      string        `json:"writer"`
-	WriteDate   string        `json:"write_date"`
-	Comments    []BlogComment `json:"comments"`
-	OriginalURL string        `json:"original_url"`
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	ContentHTML string `json:"content_html"`
+	// SmartEditor 컴포넌트 단위로 나눈 본문
+	Blocks []content.Block `json:"blocks,omitempty"`
+	// 본문의 외부 링크 (일반 링크, 링크 카드, 임베드 동영상, 지도 장소)
+	Links       []content.Link `json:"links,omitempty"`
+	Writer      string         `json:"writer"`
+	WriteDate   string         `json:"write_date"`
+	Comments    []BlogComment  `json:"comments"`
+	OriginalURL string         `json:"original_url"`
+	Media       []media.File   `json:"media,omitempty"`
 }
 
 // BlogComment represents a comment on a blog post.
@@ -63,12 +74,16 @@ const (
 )
 
 // 게시글 목록 가져오기 - 개선된 버전
-func GetBlogPostList(blogID string, page int) ([]BlogPost, error) {
-	url := fmt.Sprintf("https://blog.naver.com/PostTitleListAsync.naver?blogId=%s&viewdate=&currentPage=%d&categoryNo=0&parentCategoryNo=&countPerPage=5", blogID, page)
+func (c *Crawler) GetBlogPostList(ctx context.Context, blogID string, page int) ([]BlogPost, error) {
+	url := fmt.Sprintf("%s/PostTitleListAsync.naver?blogId=%s&viewdate=&currentPage=%d&categoryNo=0&parentCategoryNo=&countPerPage=5", c.blogBaseURL, blogID, page)
 
-	resp, err := client.Get(url)
+	// JSON 을 요청해 로그인·캡차·점검 안내 페이지가 돌아오면 파싱 전에 오류로 처리
+	resp, err := c.doRequest(ctx, url, func(req *http.Request) error {
+		setDefaultHeader(req, "Accept", "application/json, text/plain, */*")
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("게시글 목록 요청 실패: %v", err)
+		return nil, fmt.Errorf("게시글 목록 요청 실패: %w", err)
 	}
 	defer resp.Body.Close()
 
@@ -78,11 +93,8 @@ func GetBlogPostList(blogID string, page int) ([]BlogPost, error) {
 		return nil, fmt.Errorf("응답 읽기 실패: %v", err)
 	}
 
-	// 작은따옴표를 큰따옴표로 변환
-	jsonStr := strings.ReplaceAll(string(body), "'", "\"")
-
 	var blogResponse NaverBlogResponse
-	if err := json.Unmarshal([]byte(jsonStr), &blogResponse); err != nil {
+	if err := json.Unmarshal(blogListJSON(body), &blogResponse); err != nil {
 		return nil, fmt.Errorf("JSON 파싱 실패: %v", err)
 	}
 
@@ -96,7 +108,7 @@ func GetBlogPostList(blogID string, page int) ([]BlogPost, error) {
 			ID:          post.LogNo,
 			Title:       post.Title,
 			WriteDate:   post.AddDate,
-			OriginalURL: fmt.Sprintf("https://blog.naver.com/%s/%s", blogID, post.LogNo),
+			OriginalURL: fmt.Sprintf("%s/%s/%s", c.blogBaseURL, blogID, post.LogNo),
 		})
 	}
 
@@ -107,11 +119,22 @@ func GetBlogPostList(blogID string, page int) ([]BlogPost, error) {
 	return posts, nil
 }
 
+// PostTitleListAsync 응답을 JSON 으로 파싱할 수 있게 고치기
+// 제목 등의 작은따옴표가 JSON 에 없는 이스케이프(\')로 들어 있어 그대로는 파싱되지 않으며,
+// 문자열을 작은따옴표로 감싼 응답은 작은따옴표를 큰따옴표로 바꿉니다.
+func blogListJSON(body []byte) []byte {
+	fixed := []byte(strings.ReplaceAll(string(body), `\'`, "'"))
+	if json.Valid(fixed) {
+		return fixed
+	}
+	return []byte(strings.ReplaceAll(string(body), "'", "\""))
+}
+
 // 게시글 상세 정보 가져오기 - 개선된 버전
-func GetBlogPostDetail(blogID string, articleID string) (BlogPost, error) {
-	url := fmt.Sprintf("https://blog.naver.com/PostView.naver?blogId=%s&logNo=%s", blogID, articleID)
+func (c *Crawler) GetBlogPostDetail(ctx context.Context, blogID string, articleID string) (BlogPost, error) {
+	url := fmt.Sprintf("%s/PostView.naver?blogId=%s&logNo=%s", c.blogBaseURL, blogID, articleID)
 
-	resp, err := client.Get(url)
+	resp, err := c.get(ctx, url)
 	if err != nil {
 		return BlogPost{}, fmt.Errorf("게시글 상세 로드 실패: %v", err)
 	}
@@ -122,22 +145,18 @@ func GetBlogPostDetail(blogID string, articleID string) (BlogPost, error) {
 		return BlogPost{}, fmt.Errorf("HTML 파싱 실패: %v", err)
 	}
 
-	// script 태그 제거
-	doc.Find("script").Remove()
+	// script 태그 제거 (SmartEditor 컴포넌트 데이터는 블록 속성에 쓰므로 유지)
+	doc.Find("script").Not(".__se_module_data").Remove()
 
 	// title 태그에서 제목 추출
 	title := doc.Find("title").Text()
 	// 네이버 블로그 제목에서 불필요한 부분 제거 (예: " : 네이버 블로그")
 	title = strings.Split(title, " : 네이버 블로그")[0]
 
-	// .se-main-container 내의 콘텐츠만 추출
-	content := doc.Find(".se-main-container").Text()
-
-	// 연속된 공백과 줄바꿈 정리
-	content = strings.Join(strings.Fields(content), " ")
-
-	// 불필요한 공백 제거
-	content = strings.TrimSpace(content)
+	// .se-main-container 내의 콘텐츠만 추출 (미디어 추출과 블록 분리를 위해 HTML 도 보관)
+	container := doc.Find(".se-main-container")
+	contentHTML, _ := container.Html()
+	contentHTML = strings.TrimSpace(contentHTML)
 
 	blogPost := BlogPost{
 		ID:          articleID,
@@ -145,7 +164,9 @@ func GetBlogPostDetail(blogID string, articleID string) (BlogPost, error) {
 		Title:       title,
 		Writer:      utils.FindFirstMatch(doc, writerSelectors),
 		WriteDate:   utils.FindFirstMatch(doc, dateSelectors),
-		Content:     content,
+		Content:     content.ToText(contentHTML),
+		ContentHTML: contentHTML,
+		Links:       content.LinksFromSelection(container),
 		Comments:    extractComments(doc),
 	}
 
@@ -153,43 +174,62 @@ func GetBlogPostDetail(blogID string, articleID string) (BlogPost, error) {
 		return blogPost, fmt.Errorf("게시글 정보를 추출할 수 없습니다")
 	}
 
+	if c.media != nil {
+		if err := c.localizeBlogMedia(ctx, &blogPost); err != nil {
+			return BlogPost{}, err
+		}
+	}
+	// 미디어 주소를 로컬 경로로 바꾼 뒤의 HTML 로 블록 분리
+	blogPost.Blocks = content.ParseBlocks(blogPost.ContentHTML)
+
 	return blogPost, nil
 }
 
-// CrawlBlog performs the main crawling operation for a Naver blog
-func CrawlBlog(blogID string, maxPages int) ([]BlogPost, error) {
+// CrawlBlog performs the main crawling operation for a Naver blog.
+// If ctx is cancelled, the posts collected so far are saved and returned with ctx.Err().
+func (c *Crawler) CrawlBlog(ctx context.Context, blogID string, maxPages int) ([]BlogPost, error) {
 	log.Printf("🚀 네이버 블로그 '%s' 크롤링 시작...", blogID)
 
-	outputDir := "output_blog"
+	outputDir := c.blogOutputDir
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return nil, fmt.Errorf("출력 디렉토리 생성 실패: %v", err)
 	}
 
 	var allPosts []BlogPost
 	var mu sync.Mutex
+	var fatalErr error
 
 	for page := 1; page <= maxPages; page++ {
-		detailedPostsOnPage, err := processPage(blogID, page, maxPages)
-		if err != nil {
-			log.Printf("⚠️ 페이지 %d 처리 실패: %v", page, err)
-			continue
-		}
+		detailedPostsOnPage, err := c.processPage(ctx, blogID, page, maxPages)
 
-		if len(detailedPostsOnPage) == 0 {
-			continue
+		if len(detailedPostsOnPage) > 0 {
+			mu.Lock()
+			allPosts = append(allPosts, detailedPostsOnPage...)
+			mu.Unlock()
+
+			if err := savePageResults(blogID, page, detailedPostsOnPage, outputDir); err != nil {
+				log.Printf("⚠️ 페이지 %d 결과 저장 실패: %v", page, err)
+			}
 		}
 
-		mu.Lock()
-		allPosts = append(allPosts, detailedPostsOnPage...)
-		mu.Unlock()
+		if ctx.Err() != nil {
+			log.Printf("🛑 크롤링 중단: 지금까지 수집한 %d개 게시글을 저장합니다.", len(allPosts))
+			break
+		}
 
-		if err := savePageResults(blogID, page, detailedPostsOnPage, outputDir); err != nil {
-			log.Printf("⚠️ 페이지 %d 결과 저장 실패: %v", page, err)
+		if isFatal(err) {
+			// 캡차, 접근 차단 등은 다음 페이지도 같은 응답이 돌아옴
+			log.Printf("⛔ 페이지 %d 처리 실패, 크롤링을 중단합니다: %v", page, err)
+			fatalErr = err
+			break
+		}
+		if err != nil {
+			log.Printf("⚠️ 페이지 %d 처리 실패: %v", page, err)
 		}
 	}
 
 	if len(allPosts) > 0 {
-		if err := saveFullResults(blogID, allPosts, outputDir); err != nil {
+		if err := c.saveFullResults(blogID, allPosts, outputDir); err != nil {
 			log.Printf("⚠️ 전체 결과 저장 실패: %v", err)
 		}
 		printResults(allPosts)
@@ -197,10 +237,38 @@ func CrawlBlog(blogID string, maxPages int) ([]BlogPost, error) {
 		fmt.Println("⚠️ 수집된 게시글이 없습니다. 블로그 ID를 확인해주세요.")
 	}
 
+	if ctx.Err() != nil {
+		return allPosts, ctx.Err()
+	}
+	if fatalErr != nil {
+		return allPosts, fatalErr
+	}
+
 	log.Printf("🎉 네이버 블로그 '%s' 크롤링 완료! 총 %d개 게시글 수집", blogID, len(allPosts))
 	return allPosts, nil
 }
 
+// CrawlBlogPost 는 블로그 게시글 하나를 가져와 출력 Sink(기본값 JSON 배열)에 저장합니다.
+func (c *Crawler) CrawlBlogPost(ctx context.Context, blogID, logNo string) (BlogPost, error) {
+	log.Printf("📖 블로그 '%s' 게시글 %s 가져오는 중...", blogID, logNo)
+
+	post, err := c.GetBlogPostDetail(ctx, blogID, logNo)
+	if err != nil {
+		return BlogPost{}, err
+	}
+
+	outputDir := c.blogOutputDir
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return post, fmt.Errorf("출력 디렉토리 생성 실패: %v", err)
+	}
+	timestamp := time.Now().Format("20060102_150405")
+	base := filepath.Join(outputDir, fmt.Sprintf("blog_%s_post_%s_%s", blogID, logNo, timestamp))
+	if err := c.saveBlogPosts(base, blogID, []BlogPost{post}); err != nil {
+		return post, err
+	}
+	return post, nil
+}
+
 // Helper functions
 
 func extractComments(doc *goquery.Document) []BlogComment {
@@ -219,12 +287,12 @@ func extractComments(doc *goquery.Document) []BlogComment {
 	return comments
 }
 
-func processPage(blogID string, page, maxPages int) ([]BlogPost, error) {
+func (c *Crawler) processPage(ctx context.Context, blogID string, page, maxPages int) ([]BlogPost, error) {
 	log.Printf("🔄 %d/%d 페이지 처리 중...", page, maxPages)
 
-	postsOnPage, err := GetBlogPostList(blogID, page)
+	postsOnPage, err := c.GetBlogPostList(ctx, blogID, page)
 	if err != nil {
-		return nil, fmt.Errorf("게시글 목록 가져오기 실패: %v", err)
+		return nil, fmt.Errorf("게시글 목록 가져오기 실패: %w", err)
 	}
 
 	if len(postsOnPage) == 0 {
@@ -235,8 +303,11 @@ func processPage(blogID string, page, maxPages int) ([]BlogPost, error) {
 	for i, post := range postsOnPage {
 		log.Printf("  📖 %d페이지 게시글 %d/%d 상세 정보 처리 중... (ID: %s)", page, i+1, len(postsOnPage), post.ID)
 
-		detail, err := GetBlogPostDetail(blogID, post.ID)
+		detail, err := c.GetBlogPostDetail(ctx, blogID, post.ID)
 		if err != nil {
+			if ctx.Err() != nil {
+				return detailedPostsOnPage, ctx.Err()
+			}
 			log.Printf("⚠️ 게시글 %s 상세 정보 가져오기 실패: %v", post.ID, err)
 			continue
 		}
@@ -253,44 +324,104 @@ func savePageResults(blogID string, page int, posts []BlogPost, outputDir string
 	timestamp := time.Now().Format("20060102_150405")
 	pageFilename := filepath.Join(outputDir, fmt.Sprintf("blog_%s_page_%d_%s.json", blogID, page, timestamp))
 
-	formattedPosts := formatPosts(posts)
-	if err := utils.SaveToJSON(formattedPosts, pageFilename); err != nil {
+	if err := output.SaveJSON(formatPosts(posts), pageFilename); err != nil {
 		return fmt.Errorf("페이지 결과 저장 실패: %v", err)
 	}
+	log.Printf("💾 저장 완료: %s", pageFilename)
 
 	return nil
 }
 
-func saveFullResults(blogID string, posts []BlogPost, outputDir string) error {
+// 전체 결과를 출력 Sink(기본값 JSON 배열)에 기록
+func (c *Crawler) saveFullResults(blogID string, posts []BlogPost, outputDir string) error {
 	timestamp := time.Now().Format("20060102_150405")
-	fullFilename := filepath.Join(outputDir, fmt.Sprintf("blog_%s_full_%s.json", blogID, timestamp))
+	base := filepath.Join(outputDir, fmt.Sprintf("blog_%s_full_%s", blogID, timestamp))
+	return c.saveBlogPosts(base, blogID, posts)
+}
 
-	formattedPosts := formatPosts(posts)
-	if err := utils.SaveToJSON(formattedPosts, fullFilename); err != nil {
+// 게시글을 base 이름의 출력 Sink(기본값 JSON 배열)에 기록
+func (c *Crawler) saveBlogPosts(base, blogID string, posts []BlogPost) error {
+	format := c.formatOr(output.FormatJSON)
+
+	sink, err := output.New(format, base)
+	if err != nil {
+		return err
+	}
+	if err := sink.Open(); err != nil {
 		return fmt.Errorf("전체 결과 저장 실패: %v", err)
 	}
+	for _, post := range posts {
+		article, comments := post.sinkRecords(blogID)
+		if err := output.WriteArticleWithComments(sink, article, comments); err != nil {
+			sink.Close()
+			return fmt.Errorf("전체 결과 저장 실패: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		return fmt.Errorf("전체 결과 저장 실패: %v", err)
+	}
+	log.Printf("💾 저장 완료: %s", output.Path(format, base))
 
 	return nil
 }
 
+// 출력 Sink 에 기록할 게시글/댓글로 변환
+func (post BlogPost) sinkRecords(blogID string) (output.Article, []output.Comment) {
+	article := output.Article{
+		Source:       "blog",
+		Board:        blogID,
+		ID:           post.ID,
+		Title:        post.Title,
+		Writer:       post.Writer,
+		WriteDate:    post.WriteDate,
+		CommentCount: len(post.Comments),
+		URL:          post.OriginalURL,
+		Content:      post.Content,
+		ContentText:  post.Content,
+		Record:       formatPost(post),
+	}
+
+	comments := make([]output.Comment, 0, len(post.Comments))
+	for _, c := range post.Comments {
+		comments = append(comments, output.Comment{
+			Source:    "blog",
+			Board:     blogID,
+			ArticleID: post.ID,
+			ID:        c.ID,
+			Writer:    c.Writer,
+			WriteDate: c.WriteDate,
+			Content:   c.Content,
+		})
+	}
+	return article, comments
+}
+
 func formatPosts(posts []BlogPost) []map[string]interface{} {
 	var formattedPosts []map[string]interface{}
 	for _, post := range posts {
-		formattedPosts = append(formattedPosts, map[string]interface{}{
-			"title":   post.Title,
-			"content": post.Content,
-			"metadata": map[string]interface{}{
-				"id":         post.ID,
-				"writer":     post.Writer,
-				"write_date": post.WriteDate,
-				"url":        post.OriginalURL,
-			},
-			"comments": post.Comments,
-		})
+		formattedPosts = append(formattedPosts, formatPost(post))
 	}
 	return formattedPosts
 }
 
+func formatPost(post BlogPost) map[string]interface{} {
+	return map[string]interface{}{
+		"title":        post.Title,
+		"content":      post.Content,
+		"content_html": post.ContentHTML,
+		"blocks":       post.Blocks,
+		"links":        post.Links,
+		"media":        post.Media,
+		"metadata": map[string]interface{}{
+			"id":         post.ID,
+			"writer":     post.Writer,
+			"write_date": post.WriteDate,
+			"url":        post.OriginalURL,
+		},
+		"comments": post.Comments,
+	}
+}
+
 func printResults(posts []BlogPost) {
 	fmt.Printf("\n📊 수집 결과 요약:\n")
 	for i, post := range posts {
@@ -0,0 +1,61 @@
+package crawling
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetBlogPostList(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantTitle string
+		wantErr   string
+	}{
+		{
+			name:      "이스케이프된 작은따옴표와 큰따옴표",
+			body:      `{"resultCode":"S","resultMessage":"","postList":[{"logNo":"223456789012","title":"It\'s \"quoted\"","categoryNo":"1","parentCategoryNo":"1","commentCount":"0","readCount":"3","addDate":"2024. 1. 2."}],"countPerPage":"5","totalCount":"1"}`,
+			wantTitle: `It's "quoted"`,
+		},
+		{
+			name:      "이스케이프하지 않은 작은따옴표",
+			body:      `{"resultCode":"S","resultMessage":"","postList":[{"logNo":"223456789012","title":"제주 '여행'","addDate":"2024. 1. 2."}],"countPerPage":"5","totalCount":"1"}`,
+			wantTitle: "제주 '여행'",
+		},
+		{
+			name:      "작은따옴표로 감싼 응답",
+			body:      `{'resultCode':'S','resultMessage':'','postList':[{'logNo':'223456789012','title':'제목','addDate':'2024. 1. 2.'}],'countPerPage':'5','totalCount':'1'}`,
+			wantTitle: "제목",
+		},
+		{
+			name:    "API 오류",
+			body:    `{"resultCode":"E","resultMessage":"존재하지 않는 블로그입니다.","postList":[]}`,
+			wantErr: "존재하지 않는 블로그입니다.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestCrawler(t, func(r *http.Request) (int, string) {
+				return http.StatusOK, tt.body
+			})
+			posts, err := c.GetBlogPostList(context.Background(), "myblog", 1)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("GetBlogPostList() error = %v, want %q 포함", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(posts) != 1 || posts[0].ID != "223456789012" || posts[0].Title != tt.wantTitle {
+				t.Fatalf("GetBlogPostList() = %+v, want 제목 %q", posts, tt.wantTitle)
+			}
+			if want := "https://blog.naver.com/myblog/223456789012"; posts[0].OriginalURL != want {
+				t.Errorf("OriginalURL = %s, want %s", posts[0].OriginalURL, want)
+			}
+		})
+	}
+}
@@ -2,34 +2,97 @@ package crawling
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
-	"math/rand"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
+	"naverCafeCrawler/internal/content"
+	"naverCafeCrawler/internal/media"
+	"naverCafeCrawler/internal/output"
+
 	"golang.org/x/sync/errgroup"
 )
 
-// 요청 간 랜덤 지연을 위한 함수
-func randomSleep() {
-	sleepTime := time.Duration(rand.Intn(2000)+1000) * time.Millisecond
-	time.Sleep(sleepTime)
+// 출력 Sink 에 기록할 게시글/댓글로 변환
+func (a CafeArticle) sinkRecords(cafeId, boardID string) (output.Article, []output.Comment) {
+	board := cafeId + "/" + boardID
+	articleID := strconv.Itoa(a.ID)
+	article := output.Article{
+		Source:               "cafe",
+		Board:                board,
+		ID:                   articleID,
+		Title:                a.Title,
+		Writer:               a.Writer.NickName,
+		WriteDate:            a.WriteDate,
+		CommentCount:         a.CommentCount,
+		ReadCount:            a.ReadCount,
+		LikeCount:            a.LikeCount,
+		URL:                  fmt.Sprintf("https://cafe.naver.com/ca-fe/cafes/%s/articles/%d", cafeId, a.ID),
+		Content:              a.ContentHTML,
+		ContentText:          a.ContentText,
+		ContentMarkdown:      a.ContentMarkdown,
+		CommentCountMismatch: a.CommentCountMismatch,
+		Deleted:              a.Deleted,
+		Record:               a,
+	}
+
+	comments := make([]output.Comment, 0, len(a.Comments))
+	for _, c := range a.Comments {
+		parentID := ""
+		if c.ParentID != 0 {
+			parentID = strconv.Itoa(c.ParentID)
+		}
+		comments = append(comments, output.Comment{
+			Source:    "cafe",
+			Board:     board,
+			ArticleID: articleID,
+			ID:        strconv.Itoa(c.ID),
+			ParentID:  parentID,
+			Depth:     c.Depth,
+			Writer:    c.Writer.NickName,
+			WriteDate: c.WriteDate,
+			LikeCount: c.LikeCount,
+			Content:   c.Content,
+			Deleted:   c.Deleted,
+			Secret:    c.Secret,
+			Sticker:   c.Sticker,
+			Image:     c.Image,
+		})
+	}
+	return article, comments
+}
+
+// 작성자 응답 구조체
+type writerInfo struct {
+	NickName        string `json:"nickName"`
+	MemberLevel     int    `json:"memberLevel"`
+	MemberLevelName string `json:"memberLevelName"`
+	Staff           bool   `json:"staff"`
+	Manager         bool   `json:"manager"`
+}
+
+func (w writerInfo) toCafeWriter() CafeWriter {
+	return CafeWriter{
+		NickName:  w.NickName,
+		Level:     w.MemberLevel,
+		LevelName: w.MemberLevelName,
+		IsStaff:   w.Staff,
+		IsManager: w.Manager,
+	}
 }
 
-// HTTP 클라이언트 설정
-var client = &http.Client{
-	Transport: &http.Transport{
-		TLSClientConfig: &tls.Config{
-			MinVersion: tls.VersionTLS12,
-		},
-	},
-	Timeout: 10 * time.Second,
+// 밀리초 타임스탬프를 표시용 문자열로 변환
+func formatTimestamp(ms int64) string {
+	return time.Unix(ms/1000, 0).Format("2006-01-02 15:04:05")
 }
 
 // 응답 구조체
@@ -38,20 +101,14 @@ type ArticleListResponse struct {
 		ArticleList []struct {
 			Type string `json:"type"`
 			Item struct {
-				ArticleId          int    `json:"articleId"`
-				CafeId             int    `json:"cafeId"`
-				Subject            string `json:"subject"`
-				WriteDateTimestamp int64  `json:"writeDateTimestamp"`
-				CommentCount       int    `json:"commentCount"`
-				ReadCount          int    `json:"readCount"`
-				LikeCount          int    `json:"likeCount"`
-				WriterInfo         struct {
-					NickName        string `json:"nickName"`
-					MemberLevel     int    `json:"memberLevel"`
-					MemberLevelName string `json:"memberLevelName"`
-					Staff           bool   `json:"staff"`
-					Manager         bool   `json:"manager"`
-				} `json:"writerInfo"`
+				ArticleId          int        `json:"articleId"`
+				CafeId             int        `json:"cafeId"`
+				Subject            string     `json:"subject"`
+				WriteDateTimestamp int64      `json:"writeDateTimestamp"`
+				CommentCount       int        `json:"commentCount"`
+				ReadCount          int        `json:"readCount"`
+				LikeCount          int        `json:"likeCount"`
+				WriterInfo         writerInfo `json:"writerInfo"`
 			} `json:"item"`
 		} `json:"articleList"`
 		PageInfo struct {
@@ -65,75 +122,197 @@ type ArticleListResponse struct {
 type ArticleDetailResponse struct {
 	Result struct {
 		Article struct {
-			ID           int    `json:"id"`
-			RefArticleID int    `json:"refArticleId"`
-			ContentHtml  string `json:"contentHtml"`
-			Subject      string `json:"subject"`
-			WriteDate    int64  `json:"writeDate"`
-			Writer       struct {
-				NickName        string `json:"nickName"`
-				MemberLevel     int    `json:"memberLevel"`
-				MemberLevelName string `json:"memberLevelName"`
-				Staff           bool   `json:"staff"`
-				Manager         bool   `json:"manager"`
-			} `json:"writer"`
-			CommentCount int `json:"commentCount"`
-			ReadCount    int `json:"readCount"`
-			LikeCount    int `json:"likeCount"`
+			ID           int        `json:"id"`
+			RefArticleID int        `json:"refArticleId"`
+			ContentHtml  string     `json:"contentHtml"`
+			Subject      string     `json:"subject"`
+			WriteDate    int64      `json:"writeDate"`
+			Writer       writerInfo `json:"writer"`
+			CommentCount int        `json:"commentCount"`
+			ReadCount    int        `json:"readCount"`
+			LikeCount    int        `json:"likeCount"`
 		} `json:"article"`
 		Comments struct {
-			Items []struct {
-				ID        int    `json:"id"`
-				Content   string `json:"content"`
-				WriteDate int64  `json:"writeDate"`
-				Writer    struct {
-					NickName        string `json:"nickName"`
-					MemberLevel     int    `json:"memberLevel"`
-					MemberLevelName string `json:"memberLevelName"`
-					Staff           bool   `json:"staff"`
-					Manager         bool   `json:"manager"`
-				} `json:"writer"`
-				LikeCount int `json:"likeCount"`
-			} `json:"items"`
+			Items []commentItem `json:"items"`
 		} `json:"comments"`
 	} `json:"result"`
 }
 
-// HTTP 요청 보내고 응답 반환하는 함수
-func getAPIResponse(url, cookie string) (*http.Response, error) {
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
+// 댓글 페이지 API 응답 구조체
+type CommentPageResponse struct {
+	Result struct {
+		Comments struct {
+			Items []commentItem `json:"items"`
+		} `json:"comments"`
+	} `json:"result"`
+}
+
+// 댓글 응답 구조체
+type commentItem struct {
+	ID        int        `json:"id"`
+	RefID     int        `json:"refId"` // 답글이면 부모 댓글 ID, 아니면 자기 ID
+	IsRef     bool       `json:"isRef"` // 답글 여부
+	Content   string     `json:"content"`
+	WriteDate int64      `json:"writeDate"`
+	Writer    writerInfo `json:"writer"`
+	LikeCount int        `json:"likeCount"`
+	IsDeleted bool       `json:"isDeleted"`
+	IsSecret  bool       `json:"isSecret"`
+	Sticker   *struct {
+		URL string `json:"url"`
+	} `json:"sticker"`
+	Image *struct {
+		URL string `json:"url"`
+	} `json:"image"`
+}
+
+func (item commentItem) toCafeComment() CafeComment {
+	comment := CafeComment{
+		ID:        item.ID,
+		Content:   item.Content,
+		Writer:    item.Writer.toCafeWriter(),
+		WriteDate: formatTimestamp(item.WriteDate),
+		LikeCount: item.LikeCount,
+		Deleted:   item.IsDeleted,
+		Secret:    item.IsSecret,
+	}
+	if item.IsRef && item.RefID != 0 && item.RefID != item.ID {
+		comment.ParentID = item.RefID
+	}
+	if item.Sticker != nil {
+		comment.Sticker = item.Sticker.URL
 	}
+	if item.Image != nil {
+		comment.Image = item.Image.URL
+	}
+	return comment
+}
 
-	// 필수 헤더만 설정
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/136.0.0.0 Safari/537.36")
-	req.Header.Set("Cookie", cookie)
-	req.Header.Set("Referer", "https://cafe.naver.com")
-	req.Header.Set("Origin", "https://cafe.naver.com")
-	req.Header.Set("X-Cafe-Product", "pc")
+// 답글의 깊이와 답글 대상 작성자 채우기
+// 부모 댓글을 수집하지 못한 답글은 깊이 1 로 둡니다.
+func linkCommentThreads(comments []CafeComment) {
+	index := make(map[int]int, len(comments))
+	for i, comment := range comments {
+		index[comment.ID] = i
+	}
 
-	randomSleep()
+	var depthOf func(i int, seen map[int]bool) int
+	depthOf = func(i int, seen map[int]bool) int {
+		parentID := comments[i].ParentID
+		if parentID == 0 {
+			return 0
+		}
+		p, ok := index[parentID]
+		if !ok || seen[parentID] {
+			return 1
+		}
+		seen[parentID] = true
+		return depthOf(p, seen) + 1
+	}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
+	for i := range comments {
+		comments[i].Depth = depthOf(i, map[int]bool{comments[i].ID: true})
+		if p, ok := index[comments[i].ParentID]; ok && comments[i].ParentID != 0 {
+			comments[i].ReplyTo = comments[p].Writer.NickName
+		}
 	}
+}
+
+// CafeWriter 는 게시글/댓글 작성자 정보입니다.
+type CafeWriter struct {
+	NickName  string `json:"nickname"`
+	Level     int    `json:"level"`
+	LevelName string `json:"level_name"`
+	IsStaff   bool   `json:"is_staff"`
+	IsManager bool   `json:"is_manager"`
+}
+
+// CafeComment 는 게시글에 달린 댓글입니다.
+// 답글(대댓글)은 ParentID 로 부모 댓글을 가리키며, 최상위 댓글의 Depth 는 0 입니다.
+type CafeComment struct {
+	ID        int        `json:"id"`
+	ParentID  int        `json:"parent_id,omitempty"`
+	Depth     int        `json:"depth"`
+	ReplyTo   string     `json:"reply_to,omitempty"` // 부모 댓글 작성자 닉네임
+	Content   string     `json:"content"`
+	Writer    CafeWriter `json:"writer"`
+	WriteDate string     `json:"write_date"`
+	LikeCount int        `json:"like_count"`
+	Deleted   bool       `json:"deleted,omitempty"`
+	Secret    bool       `json:"secret,omitempty"`
+	Sticker   string     `json:"sticker,omitempty"` // 스티커 이미지 URL
+	Image     string     `json:"image,omitempty"`   // 첨부 이미지 URL
+}
+
+// CafeArticle 은 크롤링된 카페 게시글입니다.
+type CafeArticle struct {
+	ID           int        `json:"id"`
+	Title        string     `json:"title"`
+	Writer       CafeWriter `json:"writer"`
+	WriteDate    string     `json:"write_date"`
+	WriteTime    int64      `json:"write_timestamp"` // 밀리초 단위 작성 시각
+	CommentCount int        `json:"comment_count"`
+	ReadCount    int        `json:"read_count"`
+	LikeCount    int        `json:"like_count"`
+	// 본문: 원본 HTML 과 변환한 일반 텍스트, Markdown
+	ContentHTML     string `json:"content_html"`
+	ContentText     string `json:"content_text"`
+	ContentMarkdown string `json:"content_markdown"`
+	// SmartEditor 컴포넌트 단위로 나눈 본문
+	Blocks []content.Block `json:"blocks,omitempty"`
+	// 본문의 외부 링크 (일반 링크, 링크 카드, 임베드 동영상, 지도 장소)
+	Links    []content.Link `json:"links,omitempty"`
+	Comments []CafeComment  `json:"comments"`
+	// 내려받은 미디어 (WithMediaDir 지정 시)
+	Media []media.File `json:"media,omitempty"`
+	// 수집한 댓글 수가 CommentCount 와 다르면 true
+	CommentCountMismatch bool `json:"comment_count_mismatch,omitempty"`
+	// 목록에는 있었지만 상세 정보를 가져올 때 삭제된 게시글이면 true (본문, 댓글 없음)
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// 본문 HTML 과 그 텍스트/Markdown 변환 결과, 블록과 링크 목록 설정
+func (a *CafeArticle) setContent(html string) {
+	converted := content.Convert(html)
+	a.ContentHTML = converted.HTML
+	a.ContentText = converted.Text
+	a.ContentMarkdown = converted.Markdown
+	a.Blocks = content.ParseBlocks(html)
+	a.Links = content.ExtractLinks(html)
+}
 
-	if resp.StatusCode != http.StatusOK {
-		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP 오류: %d", resp.StatusCode)
+// HTTP 요청 보내고 응답 반환하는 함수
+// 세션 풀을 쓰면 cafeId 카페에 접근할 수 있는 세션으로 보냅니다. (카페와 무관한 요청은 빈 문자열)
+func (c *Crawler) getAPIResponse(ctx context.Context, cafeId, url string) (*http.Response, error) {
+	prepare := func(req *http.Request) error {
+		// 카페 API 필수 헤더 (WithHeader 로 지정한 값이 우선)
+		setDefaultHeader(req, "Referer", "https://cafe.naver.com")
+		setDefaultHeader(req, "Origin", "https://cafe.naver.com")
+		setDefaultHeader(req, "X-Cafe-Product", "pc")
+		setDefaultHeader(req, "Accept", "application/json, text/plain, */*")
+		if c.cookie != "" {
+			req.Header.Set("Cookie", c.cookie)
+		}
+		return nil
+	}
+	if c.pool != nil {
+		return c.pooledRequest(ctx, cafeId, url, prepare)
 	}
+	return c.doRequest(ctx, url, prepare)
+}
 
-	return resp, nil
+func setDefaultHeader(req *http.Request, key, value string) {
+	if req.Header.Get(key) == "" {
+		req.Header.Set(key, value)
+	}
 }
 
 // 게시글 목록 가져오기
-func getPostList(cafeId, boardID string, page int, pageSize int, cookie string) ([]map[string]interface{}, int, error) {
-	url := fmt.Sprintf("https://apis.naver.com/cafe-web/cafe-boardlist-api/v1/cafes/%s/menus/%s/articles?page=%d&pageSize=%d&sortBy=TIME&viewType=L",
-		cafeId, boardID, page, pageSize)
+func (c *Crawler) getPostList(ctx context.Context, cafeId, boardID string, page int, pageSize int) ([]CafeArticle, int, error) {
+	url := fmt.Sprintf("%s/cafe-boardlist-api/v1/cafes/%s/menus/%s/articles?page=%d&pageSize=%d&sortBy=TIME&viewType=L",
+		c.cafeAPIBaseURL, cafeId, boardID, page, pageSize)
 
-	resp, err := getAPIResponse(url, cookie)
+	resp, err := c.getAPIResponse(ctx, cafeId, url)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -144,20 +323,18 @@ func getPostList(cafeId, boardID string, page int, pageSize int, cookie string)
 		return nil, 0, err
 	}
 
-	var posts []map[string]interface{}
+	var posts []CafeArticle
 	for _, article := range result.Result.ArticleList {
 		if article.Type == "ARTICLE" {
-			posts = append(posts, map[string]interface{}{
-				"id":            article.Item.ArticleId,
-				"title":         article.Item.Subject,
-				"writer":        article.Item.WriterInfo.NickName,
-				"writer_level":  article.Item.WriterInfo.MemberLevelName,
-				"is_staff":      article.Item.WriterInfo.Staff,
-				"is_manager":    article.Item.WriterInfo.Manager,
-				"write_date":    time.Unix(article.Item.WriteDateTimestamp/1000, 0).Format("2006-01-02 15:04:05"),
-				"comment_count": article.Item.CommentCount,
-				"read_count":    article.Item.ReadCount,
-				"like_count":    article.Item.LikeCount,
+			posts = append(posts, CafeArticle{
+				ID:           article.Item.ArticleId,
+				Title:        article.Item.Subject,
+				Writer:       article.Item.WriterInfo.toCafeWriter(),
+				WriteDate:    formatTimestamp(article.Item.WriteDateTimestamp),
+				WriteTime:    article.Item.WriteDateTimestamp,
+				CommentCount: article.Item.CommentCount,
+				ReadCount:    article.Item.ReadCount,
+				LikeCount:    article.Item.LikeCount,
 			})
 		}
 	}
@@ -165,204 +342,430 @@ func getPostList(cafeId, boardID string, page int, pageSize int, cookie string)
 }
 
 // 게시글 상세 정보 가져오기
-func getArticleDetail(cafeId string, articleId int, cookie string) (map[string]interface{}, error) {
-	url := fmt.Sprintf("https://apis.naver.com/cafe-web/cafe-articleapi/v3/cafes/%s/articles/%d?query=&useCafeId=true&requestFrom=A",
-		cafeId, articleId)
+func (c *Crawler) getArticleDetail(ctx context.Context, cafeId string, articleId int) (CafeArticle, error) {
+	url := fmt.Sprintf("%s/cafe-articleapi/v3/cafes/%s/articles/%d?query=&useCafeId=true&requestFrom=A",
+		c.cafeAPIBaseURL, cafeId, articleId)
 
-	resp, err := getAPIResponse(url, cookie)
+	resp, err := c.getAPIResponse(ctx, cafeId, url)
 	if err != nil {
-		return nil, err
+		return CafeArticle{}, err
 	}
 	defer resp.Body.Close()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return CafeArticle{}, err
+	}
 	var result ArticleDetailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, err
+	if err := json.Unmarshal(body, &result); err != nil {
+		return CafeArticle{}, err
 	}
 
 	// 게시글 정보 구성
 	article := result.Result.Article
-	articleDetail := map[string]interface{}{
-		"id":            article.ID,
-		"title":         article.Subject,
-		"content_html":  article.ContentHtml,
-		"writer":        article.Writer.NickName,
-		"writer_level":  article.Writer.MemberLevelName,
-		"is_staff":      article.Writer.Staff,
-		"is_manager":    article.Writer.Manager,
-		"write_date":    time.Unix(article.WriteDate/1000, 0).Format("2006-01-02 15:04:05"),
-		"comment_count": article.CommentCount,
-		"read_count":    article.ReadCount,
-		"like_count":    article.LikeCount,
-	}
-
-	// 댓글 정보 구성
-	var comments []map[string]interface{}
+	if article.ID == 0 {
+		// 삭제된 글 등은 200 응답 본문에 오류 코드/메시지만 담겨 오기도 함
+		code, message := parseErrorBody(body)
+		return CafeArticle{}, apiError(resp.StatusCode, url, code, message)
+	}
+	articleDetail := CafeArticle{
+		ID:           article.ID,
+		Title:        article.Subject,
+		Writer:       article.Writer.toCafeWriter(),
+		WriteDate:    formatTimestamp(article.WriteDate),
+		WriteTime:    article.WriteDate,
+		CommentCount: article.CommentCount,
+		ReadCount:    article.ReadCount,
+		LikeCount:    article.LikeCount,
+	}
+	articleDetail.setContent(article.ContentHtml)
+
+	// 댓글 정보 구성 (상세 응답에는 첫 묶음만 들어 있음)
+	seen := make(map[int]bool)
 	for _, comment := range result.Result.Comments.Items {
-		comments = append(comments, map[string]interface{}{
-			"id":           comment.ID,
-			"content":      comment.Content,
-			"writer":       comment.Writer.NickName,
-			"writer_level": comment.Writer.MemberLevelName,
-			"is_staff":     comment.Writer.Staff,
-			"is_manager":   comment.Writer.Manager,
-			"write_date":   time.Unix(comment.WriteDate/1000, 0).Format("2006-01-02 15:04:05"),
-			"like_count":   comment.LikeCount,
-		})
+		seen[comment.ID] = true
+		articleDetail.Comments = append(articleDetail.Comments, comment.toCafeComment())
+	}
+	if len(articleDetail.Comments) < articleDetail.CommentCount {
+		if err := c.fetchRemainingComments(ctx, cafeId, &articleDetail, seen); err != nil {
+			if ctx.Err() != nil {
+				return CafeArticle{}, ctx.Err()
+			}
+			log.Printf("⚠️ 게시글 %d 댓글 페이지 가져오기 실패: %v", articleId, err)
+		}
+	}
+	linkCommentThreads(articleDetail.Comments)
+	if c.media != nil {
+		if err := c.localizeCafeMedia(ctx, &articleDetail); err != nil {
+			return CafeArticle{}, err
+		}
+	}
+	if len(articleDetail.Comments) != articleDetail.CommentCount {
+		articleDetail.CommentCountMismatch = true
+		log.Printf("⚠️ 게시글 %d 댓글 수 불일치 (수집 %d개 / 표시 %d개)",
+			articleId, len(articleDetail.Comments), articleDetail.CommentCount)
 	}
-	articleDetail["comments"] = comments
 
 	return articleDetail, nil
 }
 
+// 댓글 페이지 가져오기
+func (c *Crawler) getCommentPage(ctx context.Context, cafeId string, articleId, page int) ([]commentItem, error) {
+	url := fmt.Sprintf("%s/cafe-articleapi/v2/cafes/%s/articles/%d/comments/pages/%d?requestFrom=A&orderBy=asc",
+		c.cafeAPIBaseURL, cafeId, articleId, page)
+
+	resp, err := c.getAPIResponse(ctx, cafeId, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result CommentPageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Result.Comments.Items, nil
+}
+
+// 댓글 API 를 페이지 단위로 돌며 아직 없는 댓글을 article 에 추가
+// 첫 페이지는 상세 응답의 첫 묶음과 겹치므로 건너뛰지 않고,
+// 빈 페이지나 그 이후 새 댓글이 없는 페이지를 만나면 멈춥니다.
+func (c *Crawler) fetchRemainingComments(ctx context.Context, cafeId string, article *CafeArticle, seen map[int]bool) error {
+	for page := 1; page <= maxCommentPages && len(article.Comments) < article.CommentCount; page++ {
+		items, err := c.getCommentPage(ctx, cafeId, article.ID, page)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			break
+		}
+		added := 0
+		for _, item := range items {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			article.Comments = append(article.Comments, item.toCafeComment())
+			added++
+		}
+		if added == 0 && page > 1 {
+			break
+		}
+	}
+	return nil
+}
+
+// 게시글 하나에서 가져올 최대 댓글 페이지 수
+const maxCommentPages = 1000
+
+// BoardOptions 는 게시판 크롤링 옵션입니다.
+type BoardOptions struct {
+	MaxPages int // 최대 페이지 수 (0은 무제한)
+	PageSize int // 페이지당 게시글 수 (기본값 10)
+	// Resume 이 true 이면 체크포인트가 있을 때 중단된 지점부터 같은 출력 파일에 이어서 크롤링합니다.
+	// 이때 반환값에는 이번 실행에서 수집한 게시글만 포함됩니다.
+	Resume bool
+
+	// StreamOnly 가 true 이면 수집한 게시글을 메모리에 모으지 않고 파일로만 기록합니다.
+	// 게시글이 매우 많은 게시판에 사용하며, 이때 CrawlBoard 는 빈 슬라이스를 반환합니다.
+	StreamOnly bool
+
+	// Incremental 이 true 이면 지난 실행 이후 새로 올라온 게시글만 수집해
+	// 출력 형식에 맞는 아카이브(cafe_{카페ID}_board_{게시판ID}_archive)에 이어서 기록합니다.
+	// 이 모드에서는 Resume 을 사용하지 않습니다.
+	Incremental bool
+}
+
+const defaultPageSize = 10
+
 // 게시판 크롤링
-func CrawlBoard(cafeId, boardID string, cookie string, maxPages int, pageSize int) ([]map[string]interface{}, error) {
+// 상세 정보를 가져온 게시글은 즉시 출력 Sink(기본값 _full.jsonl)에 기록됩니다.
+// ctx 가 취소되면 그때까지 수집한 게시글과 체크포인트를 저장하고 ctx.Err() 와 함께 반환합니다.
+func (c *Crawler) CrawlBoard(ctx context.Context, cafeId, boardID string, opts BoardOptions) ([]CafeArticle, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	outputDir := c.outputDir
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("출력 디렉토리 생성 실패: %v", err)
+	}
+
+	if opts.Incremental {
+		return c.crawlBoardIncremental(ctx, cafeId, boardID, opts, pageSize)
+	}
+
+	cp, err := loadCheckpoint(outputDir, cafeId, boardID, c.formatOr(output.FormatJSONL), opts.Resume)
+	if err != nil {
+		return nil, err
+	}
+
+	var allPosts []CafeArticle
+	var failedPages []int    // 목록이나 일부 게시글 상세 정보를 가져오지 못한 페이지
+	var failedArticles []int // 상세 정보를 가져오지 못한 게시글
+	var mu sync.Mutex
+
+	sink, err := output.New(cp.Format, cp.OutputBase)
+	if err != nil {
+		return nil, err
+	}
+
+	positioner, _ := sink.(output.Positioner)
+	if cp.resumed {
+		// 마지막으로 동기화한 위치 뒤에 남은, 중단으로 불완전한 행 제거
+		if positioner != nil && cp.OutputOffsets != nil {
+			if err := positioner.TruncateTo(cp.OutputOffsets); err != nil {
+				return nil, err
+			}
+		}
+		// 체크포인트에는 디스크에 기록되기 전에 중단된 게시글이 포함될 수 있으므로
+		// 출력에 실제로 기록된 게시글만 완료로 다시 표시
+		if resumable, ok := sink.(output.Resumable); ok {
+			ids, err := resumable.ArticleIDs()
+			if err != nil {
+				return nil, fmt.Errorf("이전 결과 읽기 실패: %v", err)
+			}
+			cp.resetArticles(ids)
+		}
+		log.Printf("⏯️ 체크포인트에서 이어서 크롤링 (완료 페이지 %d개, 완료 게시글 %d개, %s)",
+			len(cp.CompletedPages), cp.articleCount(), cp.FullFilename)
+	}
+
+	if err := sink.Open(); err != nil {
+		return nil, fmt.Errorf("전체 결과 파일 열기 실패: %v", err)
+	}
+	if positioner != nil {
+		cp.offsets = positioner.Offsets
+	}
+	defer func() {
+		if err := sink.Close(); err != nil {
+			log.Printf("⚠️ 전체 결과 저장 실패: %v", err)
+		}
+	}()
+
+	// 게시글을 전체 결과에 즉시 기록
+	emit := func(post CafeArticle) error {
+		article, comments := post.sinkRecords(cafeId, boardID)
+		if err := output.WriteArticleWithComments(sink, article, comments); err != nil {
+			return err
+		}
+		cp.markArticle(post.ID)
+		if !opts.StreamOnly {
+			mu.Lock()
+			allPosts = append(allPosts, post)
+			mu.Unlock()
+		}
+		return nil
+	}
+
 	// 첫 페이지를 가져와서 마지막 페이지 번호 확인
 	log.Printf("📥 첫 페이지 로딩 중...")
-	firstPagePosts, lastPage, err := getPostList(cafeId, boardID, 1, pageSize, cookie)
+	firstPagePosts, lastPage, err := c.getPostList(ctx, cafeId, boardID, 1, pageSize)
 	if err != nil {
-		return nil, fmt.Errorf("첫 페이지 로드 실패: %v", err)
+		return nil, fmt.Errorf("첫 페이지 로드 실패: %w", err)
 	}
 	log.Printf("✅ 첫 페이지 로드 완료 (%d개 게시글 발견)", len(firstPagePosts))
 
 	// 크롤링할 페이지 수 결정
 	pagesToCrawl := lastPage
-	if maxPages > 0 && maxPages < lastPage {
-		pagesToCrawl = maxPages
+	if opts.MaxPages > 0 && opts.MaxPages < lastPage {
+		pagesToCrawl = opts.MaxPages
 	}
 
-	log.Printf("🚀 총 %d 페이지 중 %d 페이지 크롤링 시작 (페이지당 %d개 게시글, 동시 처리 3페이지)",
-		lastPage, pagesToCrawl, pageSize)
+	log.Printf("🚀 총 %d 페이지 중 %d 페이지 크롤링 시작 (페이지당 %d개 게시글, 동시 처리 %d페이지)",
+		lastPage, pagesToCrawl, pageSize, c.concurrency)
 
-	var allPosts []map[string]interface{}
-	var mu sync.Mutex
+	// 한 페이지의 상세 정보를 수집하고 결과와 체크포인트 저장
+	crawlPage := func(ctx context.Context, page int, posts []CafeArticle) error {
+		if cp.isPageDone(page) {
+			log.Printf("⏭️ %d페이지는 이미 완료되어 건너뜁니다.", page)
+			return nil
+		}
+
+		// 이전 실행에서 완료한 게시글은 건너뜀
+		var todo []CafeArticle
+		for _, post := range posts {
+			if !cp.isArticleDone(post.ID) {
+				todo = append(todo, post)
+			}
+		}
 
-	// 첫 페이지 결과에 상세 정보 추가
-	log.Printf("📝 첫 페이지 게시글 상세 정보 수집 중...")
-	for i, post := range firstPagePosts {
-		articleId := post["id"].(int)
-		log.Printf("  - 게시글 %d/%d 처리 중...", i+1, len(firstPagePosts))
-		detail, err := getArticleDetail(cafeId, articleId, cookie)
+		log.Printf("📝 %d페이지 게시글 상세 정보 수집 중... (%d/%d개)", page, len(todo), len(posts))
+		done, failed, err := c.fillArticleDetails(ctx, cafeId, fmt.Sprintf("%d페이지", page), todo, emit)
 		if err != nil {
-			log.Printf("⚠️ 게시글 %d 상세 정보 가져오기 실패: %v", articleId, err)
-			continue
+			return err
 		}
-		firstPagePosts[i]["content"] = detail["content_html"]
-		firstPagePosts[i]["comments"] = detail["comments"]
-		log.Printf("  ✅ 게시글 %d 처리 완료 (댓글 %d개)", articleId, len(detail["comments"].([]map[string]interface{})))
-	}
-	allPosts = append(allPosts, firstPagePosts...)
-	log.Printf("✅ 첫 페이지 상세 정보 수집 완료")
 
-	// 첫 페이지 결과를 즉시 저장
-	timestamp := time.Now().Format("20060102_150405")
-	outputDir := "output"
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		log.Printf("⚠️ 출력 디렉토리 생성 실패: %v", err)
-	} else {
-		// 첫 페이지 전체 결과 저장
-		fullFilename := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_full.json",
-			cafeId, boardID, timestamp))
-		if err := saveToJSON(firstPagePosts, fullFilename); err != nil {
-			log.Printf("⚠️ 첫 페이지 전체 결과 저장 실패: %v", err)
+		// 페이지 경계마다 디스크에 동기화
+		if err := output.Sync(sink); err != nil {
+			return fmt.Errorf("전체 결과 기록 실패: %v", err)
+		}
+
+		// 페이지 결과를 즉시 저장
+		pageFilename := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_page_%d.json",
+			cafeId, boardID, cp.Timestamp, page))
+		if err := output.SaveJSON(done, pageFilename); err != nil {
+			log.Printf("⚠️ %d페이지 결과 저장 실패: %v", page, err)
 		} else {
-			log.Printf("💾 첫 페이지 전체 결과가 %s 파일로 저장되었습니다.", fullFilename)
+			log.Printf("💾 %d페이지 결과가 %s 파일로 저장되었습니다.", page, pageFilename)
 		}
 
-		// 첫 페이지 개별 파일 저장
-		pageFilename := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_page_1.json",
-			cafeId, boardID, timestamp))
-		if err := saveToJSON(firstPagePosts, pageFilename); err != nil {
-			log.Printf("⚠️ 첫 페이지 결과 저장 실패: %v", err)
+		// 상세 정보를 모두 가져온 페이지만 완료로 기록하고,
+		// 실패한 게시글이 있는 페이지는 재개할 때 다시 시도하도록 체크포인트를 남김
+		if len(failed) == 0 {
+			cp.markPage(page)
 		} else {
-			log.Printf("💾 첫 페이지 결과가 %s 파일로 저장되었습니다.", pageFilename)
+			ids := fromSet(failed)
+			log.Printf("⚠️ %d페이지 게시글 %d개 상세 정보 가져오기 실패: %v", page, len(ids), ids)
+			mu.Lock()
+			failedPages = append(failedPages, page)
+			failedArticles = append(failedArticles, ids...)
+			mu.Unlock()
+		}
+		if err := cp.save(); err != nil {
+			log.Printf("⚠️ 체크포인트 저장 실패: %v", err)
 		}
+
+		log.Printf("✅ %d/%d 페이지 크롤링 완료 (누적 %d개 게시글)",
+			page, pagesToCrawl, cp.articleCount())
+		return nil
 	}
 
-	// 컨텍스트와 에러그룹 생성
-	ctx := context.Background()
-	eg, ctx := errgroup.WithContext(ctx)
-	eg.SetLimit(3) // 동시 처리 제한
+	// 중단 시 지금까지 수집한 결과와 체크포인트 저장
+	interrupted := func(err error) ([]CafeArticle, error) {
+		log.Printf("🛑 크롤링 중단: 지금까지 수집한 %d개 게시글을 저장합니다.", cp.articleCount())
+		if syncErr := output.Sync(sink); syncErr != nil {
+			log.Printf("⚠️ 전체 결과 저장 실패: %v", syncErr)
+		}
+		if saveErr := cp.save(); saveErr != nil {
+			log.Printf("⚠️ 체크포인트 저장 실패: %v", saveErr)
+		} else {
+			log.Printf("💾 체크포인트가 %s 파일로 저장되었습니다. 재개 옵션으로 이어서 크롤링할 수 있습니다.", cp.path)
+		}
+		return allPosts, err
+	}
+
+	if err := crawlPage(ctx, 1, firstPagePosts); err != nil {
+		return interrupted(err)
+	}
+
+	// 에러그룹 생성
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(c.concurrency) // 동시 처리 제한
 
 	// 2페이지부터 지정된 페이지까지 크롤링
 	for page := 2; page <= pagesToCrawl; page++ {
 		page := page
+		if cp.isPageDone(page) {
+			continue
+		}
 		eg.Go(func() error {
 			select {
-			case <-ctx.Done():
-				return ctx.Err()
+			case <-egCtx.Done():
+				return egCtx.Err()
 			default:
 				log.Printf("📥 %d페이지 로딩 중...", page)
-				posts, _, err := getPostList(cafeId, boardID, page, pageSize, cookie)
+				posts, _, err := c.getPostList(egCtx, cafeId, boardID, page, pageSize)
 				if err != nil {
-					return fmt.Errorf("페이지 %d 크롤링 실패: %v", page, err)
-				}
-				log.Printf("✅ %d페이지 로드 완료 (%d개 게시글 발견)", page, len(posts))
-
-				// 각 게시글의 상세 정보 가져오기
-				log.Printf("📝 %d페이지 게시글 상세 정보 수집 중...", page)
-				for i, post := range posts {
-					articleId := post["id"].(int)
-					log.Printf("  - %d페이지 게시글 %d/%d 처리 중...", page, i+1, len(posts))
-					detail, err := getArticleDetail(cafeId, articleId, cookie)
-					if err != nil {
-						log.Printf("⚠️ 게시글 %d 상세 정보 가져오기 실패: %v", articleId, err)
-						continue
+					if egCtx.Err() != nil {
+						return egCtx.Err()
 					}
-					posts[i]["content"] = detail["content_html"]
-					posts[i]["comments"] = detail["comments"]
-					log.Printf("  ✅ %d페이지 게시글 %d 처리 완료 (댓글 %d개)",
-						page, articleId, len(detail["comments"].([]map[string]interface{})))
-				}
-
-				mu.Lock()
-				allPosts = append(allPosts, posts...)
-				mu.Unlock()
-
-				// 페이지 결과를 즉시 저장
-				pageFilename := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_page_%d.json",
-					cafeId, boardID, timestamp, page))
-				if err := saveToJSON(posts, pageFilename); err != nil {
-					log.Printf("⚠️ %d페이지 결과 저장 실패: %v", page, err)
-				} else {
-					log.Printf("💾 %d페이지 결과가 %s 파일로 저장되었습니다.", page, pageFilename)
-				}
-
-				// 전체 결과 업데이트
-				fullFilename := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_board_%s_%s_full.json",
-					cafeId, boardID, timestamp))
-				if err := saveToJSON(allPosts, fullFilename); err != nil {
-					log.Printf("⚠️ 전체 결과 업데이트 실패: %v", err)
-				} else {
-					log.Printf("💾 전체 결과가 업데이트되었습니다. (현재 %d개 게시글)", len(allPosts))
+					// 세션 만료, 접근 차단 등은 나머지 페이지도 실패하므로 중단
+					if isFatal(err) {
+						return fmt.Errorf("페이지 %d 크롤링 실패: %w", page, err)
+					}
+					// 재시도 후에도 실패한 페이지는 기록만 하고 나머지 페이지는 계속 진행
+					log.Printf("⚠️ 페이지 %d 크롤링 실패: %v", page, err)
+					mu.Lock()
+					failedPages = append(failedPages, page)
+					mu.Unlock()
+					return nil
 				}
+				log.Printf("✅ %d페이지 로드 완료 (%d개 게시글 발견)", page, len(posts))
 
-				log.Printf("✅ %d/%d 페이지 크롤링 완료 (누적 %d개 게시글)",
-					page, pagesToCrawl, len(allPosts))
-				return nil
+				return crawlPage(egCtx, page, posts)
 			}
 		})
 	}
 
-	err = eg.Wait()
-	if err != nil {
-		return nil, err
+	if err := eg.Wait(); err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return interrupted(err)
 	}
 
-	log.Printf("🎉 크롤링 완료! 총 %d개 게시글 수집", len(allPosts))
+	if len(failedPages) > 0 {
+		sort.Ints(failedPages)
+		log.Printf("⚠️ 크롤링 실패 페이지 %d개: %v (재개 옵션으로 다시 시도할 수 있습니다)", len(failedPages), failedPages)
+		if len(failedArticles) > 0 {
+			sort.Ints(failedArticles)
+			log.Printf("⚠️ 상세 정보를 가져오지 못한 게시글 %d개: %v", len(failedArticles), failedArticles)
+		}
+		if err := cp.save(); err != nil {
+			log.Printf("⚠️ 체크포인트 저장 실패: %v", err)
+		}
+	} else if err := cp.remove(); err != nil {
+		log.Printf("⚠️ 체크포인트 삭제 실패: %v", err)
+	}
+
+	log.Printf("🎉 크롤링 완료! 총 %d개 게시글 수집 (%s)", cp.articleCount(), cp.FullFilename)
 	return allPosts, nil
 }
 
-// JSON 저장 함수
-func saveToJSON(data interface{}, filename string) error {
-	jsonData, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("JSON 변환 실패: %v", err)
-	}
-
-	if err := os.WriteFile(filename, jsonData, 0644); err != nil {
-		return fmt.Errorf("파일 저장 실패: %v", err)
+// 게시글 목록에 상세 정보(본문, 댓글) 채우기
+// 상세 정보를 가져온 게시글은 emit 으로 즉시 넘기고, 가져오지 못한 게시글 ID 는 failed 로 반환합니다.
+// 삭제된 게시글은 목록 정보에 Deleted 를 표시한 묘비(tombstone)로 넘깁니다.
+// ctx 가 취소되거나 세션 만료, 접근 차단 등으로 더 진행할 수 없으면 그때까지 처리한 게시글과 오류를 반환합니다.
+func (c *Crawler) fillArticleDetails(ctx context.Context, cafeId string, label string, posts []CafeArticle, emit func(CafeArticle) error) ([]CafeArticle, map[int]bool, error) {
+	var done []CafeArticle
+	failed := make(map[int]bool)
+	for i, post := range posts {
+		articleId := post.ID
+		log.Printf("  - %s 게시글 %d/%d 처리 중...", label, i+1, len(posts))
+		detail, err := c.getArticleDetail(ctx, cafeId, articleId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return done, failed, ctx.Err()
+			}
+			switch {
+			case isFatal(err):
+				log.Printf("⛔ 게시글 %d 상세 정보 가져오기 실패, 크롤링을 중단합니다: %v", articleId, err)
+				return done, failed, fmt.Errorf("게시글 %d: %w", articleId, err)
+			case errors.Is(err, ErrArticleDeleted):
+				log.Printf("🪦 게시글 %d 삭제됨, 목록 정보만 기록합니다.", articleId)
+				post.Deleted = true
+			default:
+				log.Printf("⚠️ 게시글 %d 상세 정보 가져오기 실패: %v", articleId, err)
+				failed[articleId] = true
+				continue
+			}
+		} else {
+			post.fillDetail(detail)
+		}
+		if emit != nil {
+			if err := emit(post); err != nil {
+				return done, failed, fmt.Errorf("게시글 %d 기록 실패: %v", articleId, err)
+			}
+		}
+		done = append(done, post)
+		if !post.Deleted {
+			log.Printf("  ✅ %s 게시글 %d 처리 완료 (댓글 %d개)",
+				label, articleId, len(detail.Comments))
+		}
 	}
+	return done, failed, nil
+}
 
-	return nil
+// 목록에서 가져온 게시글에 상세 정보(본문, 댓글, 미디어) 채우기
+func (a *CafeArticle) fillDetail(detail CafeArticle) {
+	a.ContentHTML = detail.ContentHTML
+	a.ContentText = detail.ContentText
+	a.ContentMarkdown = detail.ContentMarkdown
+	a.Blocks = detail.Blocks
+	a.Links = detail.Links
+	a.Media = detail.Media
+	a.Comments = detail.Comments
+	a.CommentCountMismatch = detail.CommentCountMismatch
 }
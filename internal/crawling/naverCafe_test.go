@@ -0,0 +1,176 @@
+package crawling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var commentPageRe = regexp.MustCompile(`/comments/pages/(\d+)$`)
+
+// 댓글 ID 목록을 댓글 API 의 items 배열로
+func commentItemsJSON(ids []int) string {
+	items := make([]string, len(ids))
+	for i, id := range ids {
+		items[i] = fmt.Sprintf(`{"id":%d,"content":"댓글 %d","writer":{"nickName":"w%d"}}`, id, id, id)
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func TestGetArticleDetailComments(t *testing.T) {
+	tests := []struct {
+		name         string
+		commentCount int
+		first        []int         // 상세 응답에 들어 있는 첫 묶음
+		pages        map[int][]int // 댓글 페이지별 댓글 (없는 페이지는 빈 목록)
+		pageStatus   int           // 0 이 아니면 댓글 페이지 응답 상태 코드
+		wantIDs      []int
+		wantPages    []int // 요청한 댓글 페이지
+		wantMismatch bool
+	}{
+		{
+			name:         "첫 묶음에 모두 있음",
+			commentCount: 2,
+			first:        []int{1, 2},
+			wantIDs:      []int{1, 2},
+		},
+		{
+			name:         "여러 페이지",
+			commentCount: 5,
+			first:        []int{1, 2},
+			pages:        map[int][]int{1: {1, 2}, 2: {3, 4}, 3: {5}},
+			wantIDs:      []int{1, 2, 3, 4, 5},
+			wantPages:    []int{1, 2, 3},
+		},
+		{
+			name:         "빈 페이지에서 멈춤",
+			commentCount: 5,
+			first:        []int{1, 2},
+			pages:        map[int][]int{1: {1, 2, 3}},
+			wantIDs:      []int{1, 2, 3},
+			wantPages:    []int{1, 2},
+			wantMismatch: true,
+		},
+		{
+			name:         "새 댓글이 없는 페이지에서 멈춤",
+			commentCount: 4,
+			first:        []int{1},
+			pages:        map[int][]int{1: {1, 2}, 2: {3}, 3: {3}, 4: {3}},
+			wantIDs:      []int{1, 2, 3},
+			wantPages:    []int{1, 2, 3},
+			wantMismatch: true,
+		},
+		{
+			name:         "댓글 페이지 오류",
+			commentCount: 3,
+			first:        []int{1},
+			pageStatus:   http.StatusInternalServerError,
+			wantIDs:      []int{1},
+			wantPages:    []int{1},
+			wantMismatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []int
+			c := newTestCrawler(t, func(r *http.Request) (int, string) {
+				if m := commentPageRe.FindStringSubmatch(r.URL.Path); m != nil {
+					page, _ := strconv.Atoi(m[1])
+					requested = append(requested, page)
+					if tt.pageStatus != 0 {
+						return tt.pageStatus, `{}`
+					}
+					return http.StatusOK, `{"result":{"comments":{"items":` + commentItemsJSON(tt.pages[page]) + `}}}`
+				}
+				return http.StatusOK, fmt.Sprintf(`{"result":{"article":{"id":42,"subject":"제목","contentHtml":"<p>본문</p>","commentCount":%d},"comments":{"items":%s}}}`,
+					tt.commentCount, commentItemsJSON(tt.first))
+			})
+
+			article, err := c.getArticleDetail(context.Background(), "1", 42)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var ids []int
+			for _, comment := range article.Comments {
+				ids = append(ids, comment.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("댓글 ID = %v, want %v", ids, tt.wantIDs)
+			}
+			if !reflect.DeepEqual(requested, tt.wantPages) {
+				t.Errorf("요청한 댓글 페이지 = %v, want %v", requested, tt.wantPages)
+			}
+			if article.CommentCountMismatch != tt.wantMismatch {
+				t.Errorf("CommentCountMismatch = %v, want %v", article.CommentCountMismatch, tt.wantMismatch)
+			}
+		})
+	}
+}
+
+func TestLinkCommentThreads(t *testing.T) {
+	type item struct {
+		id, refID int
+		isRef     bool
+	}
+	tests := []struct {
+		name        string
+		items       []item
+		wantParent  []int
+		wantDepth   []int
+		wantReplyTo []string
+	}{
+		{
+			name:        "최상위 댓글",
+			items:       []item{{1, 1, false}, {2, 0, false}},
+			wantParent:  []int{0, 0},
+			wantDepth:   []int{0, 0},
+			wantReplyTo: []string{"", ""},
+		},
+		{
+			name:        "답글과 답글의 답글",
+			items:       []item{{1, 1, false}, {2, 1, true}, {3, 2, true}, {4, 1, true}},
+			wantParent:  []int{0, 1, 2, 1},
+			wantDepth:   []int{0, 1, 2, 1},
+			wantReplyTo: []string{"", "w1", "w2", "w1"},
+		},
+		{
+			name:        "부모를 수집하지 못한 답글",
+			items:       []item{{5, 3, true}, {6, 5, true}},
+			wantParent:  []int{3, 5},
+			wantDepth:   []int{1, 2},
+			wantReplyTo: []string{"", "w5"},
+		},
+		{
+			name:        "순환 참조",
+			items:       []item{{1, 2, true}, {2, 1, true}},
+			wantParent:  []int{2, 1},
+			wantDepth:   []int{2, 2},
+			wantReplyTo: []string{"w2", "w1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var comments []CafeComment
+			for _, it := range tt.items {
+				comments = append(comments, commentItem{
+					ID:     it.id,
+					RefID:  it.refID,
+					IsRef:  it.isRef,
+					Writer: writerInfo{NickName: fmt.Sprintf("w%d", it.id)},
+				}.toCafeComment())
+			}
+			linkCommentThreads(comments)
+			for i, c := range comments {
+				if c.ParentID != tt.wantParent[i] || c.Depth != tt.wantDepth[i] || c.ReplyTo != tt.wantReplyTo[i] {
+					t.Errorf("댓글 %d = (parent %d, depth %d, reply_to %q), want (%d, %d, %q)",
+						c.ID, c.ParentID, c.Depth, c.ReplyTo, tt.wantParent[i], tt.wantDepth[i], tt.wantReplyTo[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package crawling
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// 점검 안내 페이지에 Retry-After 가 없을 때 다시 요청하기 전 최소 대기 시간
+const maintenanceBackoff = 1 * time.Minute
+
+// 응답이 HTML 페이지인지 판단할 때 미리 읽는 크기
+const sniffSize = 512
+
+// WithDebugDir 은 JSON 대신 돌아온 HTML 페이지(로그인, 캡차, 점검 안내 등)의 응답 본문을 저장할 디렉토리를 지정합니다.
+// 지정하지 않으면 출력 디렉토리(블로그 요청은 블로그 출력 디렉토리) 아래 debug 에 저장합니다.
+func WithDebugDir(dir string) Option {
+	return func(c *Crawler) {
+		c.debugDir = dir
+	}
+}
+
+// HTML 페이지 본문에 포함된 문구로 원인 판단 (순서대로 먼저 맞는 것, 소문자로 비교)
+// 로그인 페이지는 다른 페이지의 로그인 링크와 구분하도록 로그인 폼의 주소와 이름만 찾습니다.
+var pageKeywords = []struct {
+	keywords []string
+	err      error
+}{
+	{[]string{"captcha", "자동입력 방지", "자동 입력 방지", "자동입력방지", "보안문자", "보안 문자"}, ErrCaptcha},
+	{[]string{"시스템 점검", "서비스 점검", "점검 중", "점검중", "정기 점검", "maintenance"}, ErrMaintenance},
+	{[]string{"nidlogin.login", "frmnidlogin", "로그인이 필요"}, ErrSessionExpired},
+	{[]string{"비정상적인 접근", "비정상적인 요청", "접근이 차단", "이용이 제한"}, ErrBlockedPage},
+}
+
+var titleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// Accept 헤더로 JSON 을 요청했는지 (카페 API, 블로그 목록, 로그인 확인 요청)
+func expectsJSON(req *http.Request) bool {
+	return strings.Contains(req.Header.Get("Accept"), "json")
+}
+
+// 응답이 JSON 대신 HTML 페이지인지 판단
+// 옛 .naver 주소(블로그 PostTitleListAsync 등)는 JSON 을 text/html 로 보내므로 본문을 먼저 보고,
+// '{' 나 '[' 로 시작하면 JSON, '<' 로 시작하면 HTML 로 봅니다. 본문으로 알 수 없을 때만 Content-Type 을 따릅니다.
+func isPage(contentType string, head []byte) bool {
+	head = bytes.TrimSpace(head)
+	if len(head) > 0 {
+		switch head[0] {
+		case '{', '[':
+			return false
+		case '<':
+			return true
+		}
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.Contains(mediaType, "html")
+}
+
+// HTML 페이지의 원인 판단 (알 수 없으면 nil)
+// 로그인 페이지로 리다이렉트되었으면 최종 주소(nid.naver.com)로 알 수 있습니다.
+func classifyPage(final *url.URL, body []byte) error {
+	if final != nil && final.Hostname() == "nid.naver.com" {
+		return ErrSessionExpired
+	}
+	lower := strings.ToLower(string(body))
+	for _, k := range pageKeywords {
+		for _, keyword := range k.keywords {
+			if strings.Contains(lower, keyword) {
+				return k.err
+			}
+		}
+	}
+	return nil
+}
+
+// HTML 페이지의 <title>
+func pageTitle(body []byte) string {
+	m := titleRe.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " ")
+}
+
+// 200 응답이 JSON 대신 HTML 페이지이면 원인을 판단해 오류를 반환합니다.
+// JSON 이면 미리 읽은 부분을 되돌려 resp.Body 를 처음부터 읽을 수 있게 둡니다.
+func (c *Crawler) checkJSONResponse(resp *http.Response, url string) error {
+	br := bufio.NewReaderSize(resp.Body, sniffSize)
+	head, _ := br.Peek(sniffSize)
+	if !isPage(resp.Header.Get("Content-Type"), head) {
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{br, resp.Body}
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(br, maxErrorBody))
+	resp.Body.Close()
+	return c.pageError(resp, url, body)
+}
+
+// HTML 페이지 응답의 오류 (본문은 디버깅을 위해 파일로 저장)
+func (c *Crawler) pageError(resp *http.Response, url string, body []byte) *StatusError {
+	cause := classifyPage(resp.Request.URL, body)
+	title := pageTitle(body)
+	if cause == nil {
+		cause = classify(resp.StatusCode, url, "", title)
+	}
+	if cause == nil {
+		cause = ErrUnexpectedResponse
+	}
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if contentType == "" {
+		contentType = "HTML"
+	}
+
+	statusErr := &StatusError{
+		StatusCode:  resp.StatusCode,
+		URL:         url,
+		RetryAfter:  parseRetryAfter(resp.Header.Get("Retry-After")),
+		Message:     title,
+		Err:         cause,
+		ContentType: contentType,
+	}
+	if cause == ErrMaintenance && statusErr.RetryAfter < maintenanceBackoff {
+		statusErr.RetryAfter = maintenanceBackoff
+	}
+	if path, err := c.saveDebugBody(url, cause, body); err != nil {
+		log.Printf("⚠️ 응답 본문 저장 실패: %v", err)
+	} else {
+		statusErr.BodyFile = path
+	}
+	return statusErr
+}
+
+// 원인별 파일 이름
+var pageKinds = map[error]string{
+	ErrSessionExpired:     "login",
+	ErrCaptcha:            "captcha",
+	ErrMaintenance:        "maintenance",
+	ErrBlockedPage:        "blocked",
+	ErrRateLimited:        "ratelimited",
+	ErrUnexpectedResponse: "unexpected",
+}
+
+// 응답 본문을 디버그 디렉토리에 저장하고 경로를 반환
+// 파일 첫 줄에 요청 주소를 HTML 주석으로 남깁니다.
+func (c *Crawler) saveDebugBody(url string, cause error, body []byte) (string, error) {
+	dir := c.debugDir
+	if dir == "" {
+		base := c.outputDir
+		if strings.HasPrefix(url, c.blogBaseURL) {
+			base = c.blogOutputDir
+		}
+		dir = filepath.Join(base, "debug")
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	kind, ok := pageKinds[cause]
+	if !ok {
+		kind = "page"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s_%s.html", time.Now().Format("20060102_150405.000000"), kind))
+	data := append([]byte(fmt.Sprintf("<!-- %s -->\n", strings.ReplaceAll(url, "--", "%2D%2D"))), body...)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,60 @@
+package crawling
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestIsPage(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        bool
+	}{
+		{"JSON", "application/json;charset=UTF-8", `{"result":{}}`, false},
+		{"text/html 로 보낸 JSON 객체", "text/html;charset=UTF-8", "\r\n  {\"resultCode\":\"S\"}", false},
+		{"text/html 로 보낸 JSON 배열", "text/html", `[1,2]`, false},
+		{"HTML", "text/html; charset=utf-8", "<!DOCTYPE html><html></html>", true},
+		{"Content-Type 없는 HTML", "", "\n<html><title>로그인</title></html>", true},
+		{"JSON 으로 보낸 HTML", "application/json", "<html></html>", true},
+		{"본문 없는 HTML", "text/html", "", true},
+		{"본문 없는 JSON", "application/json", "", false},
+		{"알 수 없는 본문", "text/plain", "OK", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isPage(tt.contentType, []byte(tt.body)); got != tt.want {
+				t.Errorf("isPage(%q, %q) = %v, want %v", tt.contentType, tt.body, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassifyPage(t *testing.T) {
+	tests := []struct {
+		name  string
+		final string
+		body  string
+		want  error
+	}{
+		{"로그인 페이지로 리다이렉트", "https://nid.naver.com/nidlogin.login?url=x", "", ErrSessionExpired},
+		{"로그인 폼", "https://cafe.naver.com/x", `<form name="frmNIDLogin" action="https://nid.naver.com/nidlogin.login">`, ErrSessionExpired},
+		{"캡차", "https://cafe.naver.com/x", "<html><title>보안문자 입력</title></html>", ErrCaptcha},
+		{"점검", "https://cafe.naver.com/x", "<html><p>서비스 점검 중입니다</p></html>", ErrMaintenance},
+		{"차단", "https://cafe.naver.com/x", "<html>비정상적인 접근이 감지되었습니다</html>", ErrBlockedPage},
+		{"알 수 없는 페이지", "https://cafe.naver.com/x", "<html><title>카페</title></html>", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			final, err := url.Parse(tt.final)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := classifyPage(final, []byte(tt.body)); !errors.Is(got, tt.want) {
+				t.Errorf("classifyPage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
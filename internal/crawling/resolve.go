@@ -0,0 +1,69 @@
+package crawling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"naverCafeCrawler/internal/cafeurl"
+)
+
+// 카페 정보 API 응답 구조체
+type CafeInfoResponse struct {
+	Message struct {
+		Status string `json:"status"`
+		Error  struct {
+			Code string `json:"code"`
+			Msg  string `json:"msg"`
+		} `json:"error"`
+		Result struct {
+			CafeInfoView struct {
+				CafeID   int    `json:"cafeId"`
+				CafeURL  string `json:"cafeUrl"`
+				CafeName string `json:"cafeName"`
+			} `json:"cafeInfoView"`
+		} `json:"result"`
+	} `json:"message"`
+}
+
+// LookupCafeID 는 카페 별칭(cafe.naver.com/{별칭})의 숫자 카페 ID 를 카페 정보 API 로 조회합니다.
+func (c *Crawler) LookupCafeID(ctx context.Context, alias string) (string, error) {
+	infoURL := fmt.Sprintf("%s/cafe2/CafeGateInfo.json?cluburl=%s", c.cafeAPIBaseURL, url.QueryEscape(alias))
+
+	resp, err := c.getAPIResponse(ctx, "", infoURL)
+	if err != nil {
+		return "", fmt.Errorf("카페 정보 요청 실패: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result CafeInfoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("카페 정보 파싱 실패: %v", err)
+	}
+	if status := result.Message.Status; status != "" && status != "200" {
+		statusCode, _ := strconv.Atoi(status)
+		return "", fmt.Errorf("카페 정보 API 오류: %w", apiError(statusCode, infoURL, result.Message.Error.Code, result.Message.Error.Msg))
+	}
+	if result.Message.Result.CafeInfoView.CafeID == 0 {
+		return "", fmt.Errorf("카페를 찾을 수 없습니다: %s", alias)
+	}
+	return strconv.Itoa(result.Message.Result.CafeInfoView.CafeID), nil
+}
+
+// ResolveCafeURL 은 카페/게시판/게시글 주소, 별칭, 카페 ID 를 읽어 카페 ID 가 채워진 Ref 를 반환합니다.
+// 주소에 별칭만 있으면 카페 정보 API 로 카페 ID 를 조회합니다.
+func (c *Crawler) ResolveCafeURL(ctx context.Context, raw string) (cafeurl.Ref, error) {
+	ref, err := cafeurl.Parse(raw)
+	if err != nil {
+		return cafeurl.Ref{}, err
+	}
+	if ref.NeedsLookup() {
+		ref.CafeID, err = c.LookupCafeID(ctx, ref.Alias)
+		if err != nil {
+			return cafeurl.Ref{}, err
+		}
+	}
+	return ref, nil
+}
@@ -0,0 +1,212 @@
+package crawling
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"naverCafeCrawler/internal/ratelimit"
+)
+
+// RetryPolicy 는 일시적인 요청 실패에 대한 재시도 정책입니다.
+type RetryPolicy struct {
+	MaxAttempts int           // 최초 요청을 포함한 최대 시도 횟수 (1이면 재시도 없음)
+	BaseDelay   time.Duration // 첫 재시도 전 대기 시간, 이후 시도마다 두 배로 증가
+	MaxDelay    time.Duration // 재시도 대기 시간 상한
+}
+
+// DefaultRetryPolicy 는 New 가 사용하는 기본 재시도 정책입니다.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   2 * time.Second,
+	MaxDelay:    1 * time.Minute,
+}
+
+// WithRetryPolicy 는 요청 재시도 정책을 지정합니다.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Crawler) {
+		c.retry = p
+	}
+}
+
+// StatusError 는 200 이 아닌 HTTP 응답이나 네이버 API 가 본문에 담아 돌려준 오류,
+// 또는 JSON 대신 돌아온 로그인·캡차·점검 안내 같은 HTML 페이지를 나타냅니다.
+// 원인을 알 수 있으면 Err 에 ErrSessionExpired 등이 들어 있어 errors.Is 로 확인할 수 있습니다.
+type StatusError struct {
+	StatusCode  int
+	URL         string
+	RetryAfter  time.Duration // Retry-After 헤더 값 (없으면 0)
+	Code        string        // 네이버 오류 코드 (없으면 빈 문자열)
+	Message     string        // 네이버 오류 메시지 (HTML 페이지면 페이지 제목)
+	Err         error         // 오류 원인 (알 수 없으면 nil)
+	ContentType string        // HTML 페이지 응답의 Content-Type (API 오류면 빈 문자열)
+	BodyFile    string        // 디버깅을 위해 HTML 페이지 응답 본문을 저장한 파일
+}
+
+func (e *StatusError) Error() string {
+	return describeStatus(e)
+}
+
+func (e *StatusError) Unwrap() error {
+	return e.Err
+}
+
+// 재시도 대기 시간 계산 (지수 백오프 + 지터)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d <= 0 {
+		return 0
+	}
+	// 대기 시간의 50~100% 사이에서 무작위로 선택
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// 재시도 가능한 오류인지 판단
+// 429, 5xx, 점검 안내나 알 수 없는 HTML 페이지, 타임아웃, 연결 끊김은 재시도하고 401/403/404, 캡차 등은 즉시 실패합니다.
+func isRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, ErrMaintenance) || errors.Is(err, ErrUnexpectedResponse) {
+		return true
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		switch statusErr.StatusCode {
+		case http.StatusRequestTimeout, http.StatusTooManyRequests:
+			return true
+		}
+		return statusErr.StatusCode >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// Retry-After 헤더 파싱 (초 단위 또는 HTTP 날짜)
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// 요청을 보낼 HTTP 클라이언트와 속도 제한기 (세션 풀을 쓰면 세션마다 다름)
+type route struct {
+	client  *http.Client
+	limiter *ratelimit.Limiter // 크롤러 전체 속도 제한기 (모든 세션이 공유)
+	account *ratelimit.Limiter // 세션 풀의 세션 전용 속도 제한기 (없으면 nil)
+	pooled  bool               // 세션 풀의 세션이면 요청 제한 등은 재시도하지 않고 바로 다른 세션으로 넘김
+}
+
+// 재시도 정책에 따라 GET 요청 수행
+// prepare 는 매 시도마다 요청을 보내기 직전에 호출됩니다.
+func (c *Crawler) doRequest(ctx context.Context, url string, prepare func(*http.Request) error) (*http.Response, error) {
+	return c.doRequestVia(ctx, route{client: c.client, limiter: c.limiter}, url, prepare)
+}
+
+// 지정한 클라이언트와 속도 제한기로 doRequest 수행
+func (c *Crawler) doRequestVia(ctx context.Context, r route, url string, prepare func(*http.Request) error) (*http.Response, error) {
+	maxAttempts := c.retry.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.doOnce(ctx, r, url, prepare)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if attempt >= maxAttempts || !isRetryable(err) {
+			return nil, err
+		}
+		// 같은 세션으로 기다렸다 다시 보내기보다 세션을 격리하고 다른 세션으로 요청
+		if r.pooled && switchesSession(err) {
+			return nil, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		var statusErr *StatusError
+		if errors.As(err, &statusErr) && statusErr.RetryAfter > wait {
+			wait = statusErr.RetryAfter
+		}
+		log.Printf("🔁 요청 실패 (%d/%d), %s 후 재시도: %v", attempt, maxAttempts, wait.Round(time.Millisecond), err)
+		if err := sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Crawler) doOnce(ctx context.Context, r route, url string, prepare func(*http.Request) error) (*http.Response, error) {
+	req, err := c.newRequest(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		if err := prepare(req); err != nil {
+			return nil, err
+		}
+	}
+
+	// 속도 제한 (세션 풀은 세션 전용 제한을 먼저 기다린 뒤, 모든 요청이 공유하는 전체 상한을 기다림)
+	if err := r.account.Wait(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
+	if err := r.limiter.Wait(ctx, req.URL.Host); err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// 오류 본문의 네이버 오류 코드/메시지로 원인 판단
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		resp.Body.Close()
+		if isPage(resp.Header.Get("Content-Type"), body) && classifyPage(resp.Request.URL, body) != nil {
+			return nil, c.pageError(resp, url, body)
+		}
+		code, message := parseErrorBody(body)
+		statusErr := apiError(resp.StatusCode, url, code, message)
+		statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		return nil, statusErr
+	}
+	// JSON 을 요청했는데 로그인·캡차·점검 안내 페이지가 200 으로 돌아오는 경우
+	if expectsJSON(req) {
+		if err := c.checkJSONResponse(resp, url); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,199 @@
+package crawling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"naverCafeCrawler/internal/output"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// 검색 API 응답 구조체
+type SearchResponse struct {
+	Result struct {
+		ArticleList []struct {
+			Type string `json:"type"`
+			Item struct {
+				ArticleId          int        `json:"articleId"`
+				CafeId             int        `json:"cafeId"`
+				Subject            string     `json:"subject"`
+				Content            string     `json:"content"`
+				WriteDateTimestamp int64      `json:"writeDateTimestamp"`
+				CommentCount       int        `json:"commentCount"`
+				ReadCount          int        `json:"readCount"`
+				LikeCount          int        `json:"likeCount"`
+				WriterInfo         writerInfo `json:"writerInfo"`
+			} `json:"item"`
+		} `json:"articleList"`
+		TotalCount int `json:"totalCount"`
+		PageInfo   struct {
+			CurrentPage int `json:"currentPage"`
+			TotalPages  int `json:"totalPages"`
+		} `json:"pageInfo"`
+	} `json:"result"`
+}
+
+// SearchOptions 는 카페 검색 크롤링 옵션입니다.
+type SearchOptions struct {
+	MaxPages   int  // 최대 페이지 수 (0은 무제한)
+	PageSize   int  // 페이지당 게시글 수 (기본값 10)
+	StreamOnly bool // true 이면 결과를 메모리에 모으지 않고 파일로만 기록
+}
+
+// 검색 API 호출 함수
+func (c *Crawler) searchArticles(ctx context.Context, cafeId, keyword string, page, pageSize int) ([]CafeArticle, int, error) {
+	searchURL := fmt.Sprintf("%s/cafe-search-api/v1/cafes/%s/articles/search?query=%s&page=%d&perPage=%d&sortBy=TIME",
+		c.cafeAPIBaseURL, cafeId, url.QueryEscape(keyword), page, pageSize)
+
+	resp, err := c.getAPIResponse(ctx, cafeId, searchURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	var result SearchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, err
+	}
+
+	var posts []CafeArticle
+	for _, article := range result.Result.ArticleList {
+		if article.Type == "ARTICLE" {
+			posts = append(posts, CafeArticle{
+				ID:           article.Item.ArticleId,
+				Title:        article.Item.Subject,
+				Writer:       article.Item.WriterInfo.toCafeWriter(),
+				WriteDate:    formatTimestamp(article.Item.WriteDateTimestamp),
+				WriteTime:    article.Item.WriteDateTimestamp,
+				CommentCount: article.Item.CommentCount,
+				ReadCount:    article.Item.ReadCount,
+				LikeCount:    article.Item.LikeCount,
+			})
+		}
+	}
+	return posts, result.Result.PageInfo.TotalPages, nil
+}
+
+// 카페 검색 결과 크롤링
+// 검색된 게시글의 상세 정보를 가져와 즉시 출력 Sink(기본값 JSONL)에 기록합니다.
+// ctx 가 취소되면 그때까지 수집한 게시글을 ctx.Err() 와 함께 반환합니다.
+func (c *Crawler) CrawlSearch(ctx context.Context, cafeId, keyword string, opts SearchOptions) ([]CafeArticle, error) {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	// 첫 페이지를 가져와서 마지막 페이지 번호 확인
+	log.Printf("🔍 검색어 '%s'로 첫 페이지 검색 중...", keyword)
+	firstPagePosts, lastPage, err := c.searchArticles(ctx, cafeId, keyword, 1, pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("첫 페이지 검색 실패: %w", err)
+	}
+	if len(firstPagePosts) == 0 {
+		log.Printf("⚠️ 검색어 '%s'에 대한 결과가 없습니다.", keyword)
+		return nil, nil
+	}
+	log.Printf("✅ 첫 페이지 검색 완료 (%d개 게시글 발견, 총 %d 페이지)", len(firstPagePosts), lastPage)
+
+	// 크롤링할 페이지 수 결정
+	pagesToCrawl := lastPage
+	if opts.MaxPages > 0 && opts.MaxPages < lastPage {
+		pagesToCrawl = opts.MaxPages
+	}
+
+	outputDir := c.outputDir
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, fmt.Errorf("출력 디렉토리 생성 실패: %v", err)
+	}
+	timestamp := time.Now().Format("20060102_150405")
+	format := c.formatOr(output.FormatJSONL)
+	base := filepath.Join(outputDir, fmt.Sprintf("cafe_%s_search_%s_%s_full",
+		cafeId, url.QueryEscape(keyword), timestamp))
+
+	sink, err := output.New(format, base)
+	if err != nil {
+		return nil, err
+	}
+	if err := sink.Open(); err != nil {
+		return nil, fmt.Errorf("전체 결과 파일 열기 실패: %v", err)
+	}
+	defer func() {
+		if err := sink.Close(); err != nil {
+			log.Printf("⚠️ 전체 결과 저장 실패: %v", err)
+		}
+	}()
+
+	var allPosts []CafeArticle
+	var collected int
+	var mu sync.Mutex
+	emit := func(post CafeArticle) error {
+		article, comments := post.sinkRecords(cafeId, "search")
+		if err := output.WriteArticleWithComments(sink, article, comments); err != nil {
+			return err
+		}
+		mu.Lock()
+		collected++
+		if !opts.StreamOnly {
+			allPosts = append(allPosts, post)
+		}
+		mu.Unlock()
+		return nil
+	}
+
+	crawlPage := func(ctx context.Context, page int, posts []CafeArticle) error {
+		log.Printf("📝 %d페이지 게시글 상세 정보 수집 중...", page)
+		if _, _, err := c.fillArticleDetails(ctx, cafeId, fmt.Sprintf("%d페이지", page), posts, emit); err != nil {
+			return err
+		}
+		if err := output.Sync(sink); err != nil {
+			return fmt.Errorf("전체 결과 기록 실패: %v", err)
+		}
+		mu.Lock()
+		log.Printf("✅ %d/%d 페이지 크롤링 완료 (누적 %d개 게시글)", page, pagesToCrawl, collected)
+		mu.Unlock()
+		return nil
+	}
+
+	log.Printf("🚀 총 %d 페이지 중 %d 페이지 크롤링 시작 (페이지당 %d개 게시글, 동시 처리 %d페이지)",
+		lastPage, pagesToCrawl, pageSize, c.concurrency)
+
+	err = crawlPage(ctx, 1, firstPagePosts)
+	if err == nil {
+		eg, egCtx := errgroup.WithContext(ctx)
+		eg.SetLimit(c.concurrency)
+		for page := 2; page <= pagesToCrawl; page++ {
+			page := page
+			eg.Go(func() error {
+				log.Printf("🔍 %d페이지 검색 중...", page)
+				posts, _, err := c.searchArticles(egCtx, cafeId, keyword, page, pageSize)
+				if err != nil {
+					if egCtx.Err() != nil {
+						return egCtx.Err()
+					}
+					log.Printf("⚠️ 페이지 %d 검색 실패: %v", page, err)
+					return nil
+				}
+				return crawlPage(egCtx, page, posts)
+			})
+		}
+		err = eg.Wait()
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		log.Printf("🛑 검색 크롤링 중단: 지금까지 수집한 %d개 게시글을 저장합니다.", collected)
+		return allPosts, err
+	}
+
+	log.Printf("🎉 검색 크롤링 완료! 총 %d개 게시글 수집 (%s)", collected, output.Path(format, base))
+	return allPosts, nil
+}
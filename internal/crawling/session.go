@@ -0,0 +1,205 @@
+package crawling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"naverCafeCrawler/internal/session"
+)
+
+// 로그인 상태 확인 요청 주소 (가입한 카페 목록, 로그인하지 않으면 401)
+const defaultSessionProbeURL = "https://apis.naver.com/cafe-home-web/cafe-home/v1/cafes/join?page=1&perPage=1"
+
+// 세션 풀에서 요청 제한, 접근 차단, 캡차를 받은 세션을 쉬게 하는 시간 (Retry-After 가 더 길면 그 값)
+const sessionQuarantine = 10 * time.Minute
+
+// WithSession 은 요청에 사용할 로그인 세션(쿠키 jar)을 지정합니다.
+// 서버가 갱신한 쿠키는 세션에 반영되어 이후 요청에 사용됩니다.
+func WithSession(s *session.Session) Option {
+	return func(c *Crawler) {
+		c.session = s
+	}
+}
+
+// WithSessionPool 은 카페 API 요청을 여러 로그인 세션에 나눠 보내도록 합니다.
+// 요청마다 그 카페에 접근할 수 있는 세션을 고르며, 세션에 Limiter 가 있으면 그 제한과 크롤러의 속도 제한을
+// 모두 기다리므로 크롤러의 속도 제한은 모든 세션을 합친 전체 상한으로 유지됩니다.
+// 로그인이 만료된 세션은 풀에서 제외하고, 요청 제한이나 접근 차단을 받은 세션은 잠시 격리한 뒤
+// 같은 요청을 다른 세션으로 다시 보냅니다. 지정하면 WithSession 은 무시됩니다.
+func WithSessionPool(p *session.Pool) Option {
+	return func(c *Crawler) {
+		c.pool = p
+	}
+}
+
+// WithSessionProbeURL 은 CheckSession 이 로그인 상태를 확인할 때 요청할 주소를 지정합니다.
+// 빈 문자열이면 쿠키가 있는지만 확인합니다.
+func WithSessionProbeURL(url string) Option {
+	return func(c *Crawler) {
+		c.probeURL = url
+	}
+}
+
+// 세션의 쿠키 jar 를 사용하는 HTTP 클라이언트 (WithHTTPClient 로 지정한 클라이언트는 복사해서 사용)
+func (c *Crawler) applySession() {
+	if c.pool != nil {
+		c.routes = make(map[*session.Account]route)
+		for _, a := range c.pool.Accounts() {
+			c.routes[a] = route{client: c.clientWithJar(a.Session), limiter: c.limiter, account: a.Limiter, pooled: true}
+		}
+		return
+	}
+	if c.session != nil {
+		c.client = c.clientWithJar(c.session)
+	}
+}
+
+func (c *Crawler) clientWithJar(s *session.Session) *http.Client {
+	client := *c.client
+	client.Jar = s
+	return &client
+}
+
+// 세션 풀에서 격리하고 바로 다른 세션으로 넘길 오류 (요청 제한, 접근 차단, 캡차)
+func switchesSession(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrBlockedPage) || errors.Is(err, ErrCaptcha)
+}
+
+// 세션 풀의 세션 하나로 요청
+// 로그인 만료, 요청 제한, 접근 차단, 캡차면 그 세션을 격리하고, 멤버 전용이면 격리하지 않고
+// 같은 요청을 아직 시도하지 않은 다른 세션으로 다시 보냅니다. 요청 제한은 같은 세션으로 재시도하지 않습니다.
+// 모든 세션이 실패하면 마지막 오류를 반환합니다.
+func (c *Crawler) pooledRequest(ctx context.Context, cafeId, url string, prepare func(*http.Request) error) (*http.Response, error) {
+	tried := make(map[*session.Account]bool)
+	var lastErr error
+	for {
+		account, err := c.pool.Acquire(ctx, cafeId, func(a *session.Account) bool { return tried[a] })
+		if err != nil {
+			if lastErr != nil && errors.Is(err, session.ErrNoAccount) {
+				return nil, lastErr
+			}
+			return nil, err
+		}
+
+		resp, err := c.doRequestVia(ctx, c.routes[account], url, prepare)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		switch {
+		case errors.Is(err, ErrSessionExpired):
+			log.Printf("🔒 세션 %s 로그인 만료, 풀에서 제외합니다: %v", account.Name, err)
+			account.Quarantine(0, err)
+		case switchesSession(err):
+			d := sessionQuarantine
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && statusErr.RetryAfter > d {
+				d = statusErr.RetryAfter
+			}
+			log.Printf("🧊 세션 %s %s 동안 격리: %v", account.Name, d, err)
+			account.Quarantine(d, err)
+		case errors.Is(err, ErrMembersOnly):
+			log.Printf("🔁 세션 %s 은(는) 권한이 없어 다른 세션으로 다시 요청합니다: %v", account.Name, err)
+		default:
+			return nil, err
+		}
+		tried[account] = true
+		lastErr = err
+	}
+}
+
+// CheckSession 은 크롤링 전에 로그인 상태를 확인합니다.
+// 세션(WithSession)에 인증 쿠키(NID_AUT, NID_SES)가 없거나 확인 요청이 로그인되지 않았다고 응답하면
+// ErrSessionExpired 를 반환합니다. 인증 쿠키가 session.DefaultExpiryWarning 안에 만료되면 경고를 남깁니다.
+// 세션 풀(WithSessionPool)은 세션마다 확인해 로그인되지 않은 세션을 제외하고, 남은 세션이 없을 때만 오류를 반환합니다.
+// 세션 없이 만든 Crawler 는 확인하지 않습니다.
+func (c *Crawler) CheckSession(ctx context.Context) error {
+	if c.pool != nil {
+		var (
+			alive   int
+			lastErr error
+		)
+		for _, a := range c.pool.Accounts() {
+			err := c.checkSession(ctx, a.Name, a.Session, c.routes[a])
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				log.Printf("⚠️ 세션 %s 을(를) 풀에서 제외합니다: %v", a.Name, err)
+				a.Quarantine(0, err)
+				lastErr = err
+				continue
+			}
+			alive++
+		}
+		if alive == 0 {
+			return fmt.Errorf("세션 %d개 모두 로그인 확인 실패: %w", len(c.pool.Accounts()), lastErr)
+		}
+		log.Printf("🔑 로그인 상태 확인 완료 (세션 %d/%d개 사용 가능)", alive, len(c.pool.Accounts()))
+		return nil
+	}
+
+	if c.session == nil {
+		return nil
+	}
+	if err := c.checkSession(ctx, "", c.session, route{client: c.client, limiter: c.limiter}); err != nil {
+		return err
+	}
+	log.Printf("🔑 로그인 상태 확인 완료")
+	return nil
+}
+
+// 세션 하나의 로그인 상태 확인 (name 은 로그에 표시할 세션 이름)
+func (c *Crawler) checkSession(ctx context.Context, name string, s *session.Session, r route) error {
+	if !s.LoggedIn() {
+		return fmt.Errorf("%w: 로그인 쿠키(%s, %s)가 없거나 만료되었습니다",
+			ErrSessionExpired, session.AuthCookie, session.SessionCookie)
+	}
+
+	if c.probeURL != "" {
+		resp, err := c.doRequestVia(ctx, r, c.probeURL, func(req *http.Request) error {
+			setDefaultHeader(req, "Referer", "https://section.cafe.naver.com")
+			setDefaultHeader(req, "Accept", "application/json")
+			return nil
+		})
+		if err != nil {
+			// 로그인 페이지로 리다이렉트되었거나 캡차를 요구하면 크롤링할 수 없음
+			if errors.Is(err, ErrSessionExpired) || errors.Is(err, ErrCaptcha) {
+				return err
+			}
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden) {
+				return fmt.Errorf("%w: 로그인 확인 요청이 거부되었습니다 (HTTP %d)", ErrSessionExpired, statusErr.StatusCode)
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// 확인 요청 자체의 실패(네트워크 오류 등)로는 크롤링을 막지 않음
+			log.Printf("⚠️ 로그인 상태 확인 요청 실패, 쿠키만 확인하고 계속합니다: %v", err)
+		} else {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+			resp.Body.Close()
+			code, message := parseErrorBody(body)
+			if err := apiError(resp.StatusCode, c.probeURL, code, message); errors.Is(err, ErrSessionExpired) {
+				return err
+			}
+		}
+	}
+
+	if expires := s.Expires(); s.ExpiresWithin(session.DefaultExpiryWarning) {
+		label := "로그인 쿠키"
+		if name != "" {
+			label = fmt.Sprintf("세션 %s 의 로그인 쿠키", name)
+		}
+		log.Printf("⚠️ %s가 %s 에 만료됩니다 (%s 남음). 만료 전에 쿠키를 새로 내보내세요.",
+			label, expires.Format("2006-01-02 15:04"), time.Until(expires).Round(time.Minute))
+	}
+	return nil
+}
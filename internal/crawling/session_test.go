@@ -0,0 +1,83 @@
+package crawling
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"naverCafeCrawler/internal/session"
+)
+
+func TestPooledRequestSwitchesSession(t *testing.T) {
+	const url = "https://apis.naver.com/cafe-web/cafe-articleapi/v3/cafes/1/articles/42"
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		want    error
+		wantReq int // 첫 세션으로 보낸 요청 수
+	}{
+		{"요청 제한은 재시도하지 않고 다른 세션으로", http.StatusTooManyRequests, `{}`, ErrRateLimited, 1},
+		{"접근 차단", http.StatusForbidden, `{"message":{"error":{"msg":"비정상적인 접근이 감지되었습니다."}}}`, ErrBlockedPage, 1},
+		{"로그인 만료", http.StatusUnauthorized, `{}`, ErrSessionExpired, 1},
+		{"서버 오류는 같은 세션으로 재시도", http.StatusInternalServerError, `{}`, nil, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu       sync.Mutex
+				requests = make(map[string]int)
+			)
+			handler := func(r *http.Request) (int, string) {
+				name := "b"
+				if strings.Contains(r.Header.Get("Cookie"), "NID_AUT=a") {
+					name = "a"
+				}
+				mu.Lock()
+				requests[name]++
+				mu.Unlock()
+				if name == "a" {
+					return tt.status, tt.body
+				}
+				return http.StatusOK, `{"result":{}}`
+			}
+			var accounts []*session.Account
+			for _, name := range []string{"a", "b"} {
+				s, err := session.FromHeader("NID_AUT=" + name + "; NID_SES=" + name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				accounts = append(accounts, &session.Account{Name: name, Session: s})
+			}
+			c := newTestCrawler(t, handler,
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+				WithSessionPool(session.NewPool(accounts...)))
+
+			resp, err := c.pooledRequest(context.Background(), "1", url, nil)
+			if tt.want == nil {
+				// 세션과 상관없는 오류는 다른 세션으로 넘기지 않음
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("pooledRequest() error = nil")
+				}
+				if requests["a"] != tt.wantReq || requests["b"] != 0 {
+					t.Errorf("요청 수 = %v, want a:%d", requests, tt.wantReq)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if requests["a"] != tt.wantReq || requests["b"] != 1 {
+				t.Errorf("요청 수 = %v, want a:%d b:1", requests, tt.wantReq)
+			}
+			if status := accounts[0].Status(); status == "사용 가능" || !strings.Contains(status, tt.want.Error()) {
+				t.Errorf("세션 a 상태 = %q, want %v 로 격리", status, tt.want)
+			}
+		})
+	}
+}
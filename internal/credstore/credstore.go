@@ -0,0 +1,398 @@
+// Package credstore 는 네이버 로그인 세션(쿠키)을 암호화된 파일 하나에 이름별로 보관합니다.
+//
+// 파일은 암호(passphrase) 또는 키 파일에서 PBKDF2-SHA256 으로 만든 키와 AES-256-GCM 으로 암호화합니다.
+// GCM 은 인증 암호이므로 암호가 틀리거나 파일이 변조되면 ErrWrongPassphrase 로 열리지 않습니다.
+// 쿠키는 Netscape cookies.txt 형식으로 저장해 만료 시각과 도메인을 그대로 보존합니다.
+package credstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"naverCafeCrawler/internal/session"
+)
+
+// PassphraseEnv 는 키 파일을 지정하지 않았을 때 저장소 암호를 읽는 환경 변수입니다.
+const PassphraseEnv = "NAVER_STORE_PASSPHRASE"
+
+// MinPassphrase 는 새 저장소를 만들 때 요구하는 암호의 최소 길이(바이트)입니다.
+const MinPassphrase = 8
+
+var (
+	// ErrWrongPassphrase 는 암호나 키 파일이 맞지 않거나 파일이 변조되어 복호화하지 못한 경우입니다.
+	ErrWrongPassphrase = errors.New("저장소 암호가 맞지 않거나 파일이 손상되었습니다")
+	// ErrNoSecret 은 키 파일도 암호 환경 변수도 지정하지 않은 경우입니다.
+	ErrNoSecret = errors.New("저장소 암호가 필요합니다")
+	// ErrNotFound 는 저장소에 없는 이름입니다.
+	ErrNotFound = errors.New("저장소에 없는 세션")
+)
+
+// 파일 형식과 키 생성 설정
+const (
+	fileVersion   = 1
+	kdfName       = "pbkdf2-sha256"
+	kdfIterations = 600000
+	saltSize      = 16
+	keySize       = 32 // AES-256
+	keyFileSize   = 32 // GenerateKeyFile 이 만드는 무작위 키 (hex 로 기록)
+)
+
+// 암호화된 파일 (salt, nonce, ciphertext 는 hex)
+type envelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       string `json:"salt"`
+	Nonce      string `json:"nonce"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// 복호화한 내용
+type payload struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Entry 는 저장소에 보관한 세션 하나입니다.
+type Entry struct {
+	Name    string `json:"name"`
+	Cookies string `json:"cookies"` // Netscape cookies.txt 형식
+	// 이 세션으로 접근할 수 있는 카페 ID (세션 풀에서 사용, 비어 있으면 모든 카페)
+	Cafes     []string  `json:"cafes,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Session 은 항목의 쿠키로 로그인 세션을 만듭니다.
+func (e Entry) Session() (*session.Session, error) {
+	s, err := session.Parse([]byte(e.Cookies))
+	if err != nil {
+		return nil, fmt.Errorf("세션 %s: %v", e.Name, err)
+	}
+	return s, nil
+}
+
+// Store 는 암호화된 세션 저장소입니다. 여러 고루틴에서 동시에 사용할 수 있습니다.
+// 변경 내용은 Save 를 호출해야 파일에 기록됩니다.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	salt    []byte
+	key     []byte
+	entries map[string]Entry
+	exists  bool // 파일에서 읽었는지 (false 면 새 저장소)
+}
+
+// DefaultPath 는 기본 저장소 경로(사용자 설정 디렉토리의 navercrawl/credentials.enc)입니다.
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "credentials.enc"
+	}
+	return filepath.Join(dir, "navercrawl", "credentials.enc")
+}
+
+// Open 은 path 의 저장소를 secret(암호 또는 키 파일 내용)으로 엽니다.
+// 파일이 없으면 빈 저장소를 반환하며, 처음 Save 할 때 파일을 만듭니다.
+func Open(path string, secret []byte) (*Store, error) {
+	if len(secret) == 0 {
+		return nil, ErrNoSecret
+	}
+	s := &Store{path: path, entries: make(map[string]Entry)}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if err := s.rekey(secret); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("저장소 읽기 실패: %v", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("저장소 %s 파싱 실패: %v", path, err)
+	}
+	if env.Version != fileVersion || env.KDF != kdfName {
+		return nil, fmt.Errorf("저장소 %s: 지원하지 않는 형식 (version %d, kdf %q)", path, env.Version, env.KDF)
+	}
+	salt, err1 := hex.DecodeString(env.Salt)
+	nonce, err2 := hex.DecodeString(env.Nonce)
+	ciphertext, err3 := hex.DecodeString(env.Ciphertext)
+	if err := errors.Join(err1, err2, err3); err != nil || env.Iterations <= 0 {
+		return nil, fmt.Errorf("저장소 %s: 잘못된 파일입니다", path)
+	}
+
+	key, err := deriveKey(secret, salt, env.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("저장소 %s: 잘못된 파일입니다", path)
+	}
+	plain, err := aead.Open(nil, nonce, ciphertext, env.additionalData())
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var p payload
+	if err := json.Unmarshal(plain, &p); err != nil {
+		return nil, fmt.Errorf("저장소 %s 파싱 실패: %v", path, err)
+	}
+	for _, e := range p.Entries {
+		s.entries[e.Name] = e
+	}
+	s.salt, s.key, s.exists = salt, key, true
+	// 반복 횟수를 높인 뒤 만든 파일이 아니면 다음 저장 때 현재 설정으로 다시 키를 만듦
+	if env.Iterations != kdfIterations {
+		if err := s.rekey(secret); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Path 는 저장소 파일 경로입니다.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Exists 는 저장소를 파일에서 읽었는지 반환합니다. 새로 만든 저장소는 false 입니다.
+func (s *Store) Exists() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.exists
+}
+
+// Entries 는 저장한 세션을 이름 순으로 반환합니다.
+func (s *Store) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	return entries
+}
+
+// Get 은 name 세션을 반환합니다.
+func (s *Store) Get(name string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[name]
+	return e, ok
+}
+
+// Put 은 세션을 추가하거나 같은 이름의 세션을 바꿉니다. 만든 시각은 처음 추가한 시각을 유지합니다.
+func (s *Store) Put(e Entry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	e.CreatedAt = now
+	if old, ok := s.entries[e.Name]; ok {
+		e.CreatedAt = old.CreatedAt
+	}
+	e.UpdatedAt = now
+	s.entries[e.Name] = e
+}
+
+// Remove 는 name 세션을 지우고, 있었는지 반환합니다.
+func (s *Store) Remove(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entries[name]
+	delete(s.entries, name)
+	return ok
+}
+
+// Session 은 name 세션의 쿠키로 로그인 세션을 만듭니다.
+func (s *Store) Session(name string) (*session.Session, error) {
+	e, ok := s.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return e.Session()
+}
+
+// Update 는 서버가 갱신한 sess 의 쿠키를 name 세션에 반영해 저장하고 true 를 반환합니다.
+// 쿠키가 바뀌지 않았으면 아무것도 하지 않습니다.
+func (s *Store) Update(name string, sess *session.Session) (bool, error) {
+	if !sess.Changed() {
+		return false, nil
+	}
+	e, ok := s.Get(name)
+	if !ok {
+		return false, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	e.Cookies = sess.Netscape()
+	s.Put(e)
+	if err := s.Save(); err != nil {
+		return false, err
+	}
+	sess.MarkSaved()
+	return true, nil
+}
+
+// Rekey 는 저장소를 새 secret 으로 다시 암호화하도록 키를 바꿉니다. 파일에는 Save 할 때 기록됩니다.
+func (s *Store) Rekey(secret []byte) error {
+	if len(secret) == 0 {
+		return ErrNoSecret
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rekey(secret)
+}
+
+// 새 salt 로 키 생성
+func (s *Store) rekey(secret []byte) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	key, err := deriveKey(secret, salt, kdfIterations)
+	if err != nil {
+		return err
+	}
+	s.salt, s.key = salt, key
+	return nil
+}
+
+// Save 는 저장소를 암호화해 파일에 기록합니다. 저장할 때마다 새 nonce 를 사용하며,
+// 임시 파일에 쓴 뒤 바꿔치기하므로 기록 중에 중단되어도 기존 파일은 손상되지 않습니다.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := payload{Entries: make([]Entry, 0, len(s.entries))}
+	for _, e := range s.entries {
+		p.Entries = append(p.Entries, e)
+	}
+	sort.Slice(p.Entries, func(i, j int) bool { return p.Entries[i].Name < p.Entries[j].Name })
+	plain, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newAEAD(s.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	env := envelope{
+		Version:    fileVersion,
+		KDF:        kdfName,
+		Iterations: kdfIterations,
+		Salt:       hex.EncodeToString(s.salt),
+		Nonce:      hex.EncodeToString(nonce),
+	}
+	env.Ciphertext = hex.EncodeToString(aead.Seal(nil, nonce, plain, env.additionalData()))
+	data, err := json.MarshalIndent(env, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("저장소 디렉토리 생성 실패: %v", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("저장소 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("저장소 저장 실패: %v", err)
+	}
+	s.exists = true
+	return nil
+}
+
+// 암호화하지 않지만 변조를 막을 머리 부분 (인증 데이터로 사용)
+func (e envelope) additionalData() []byte {
+	return []byte(fmt.Sprintf("navercrawl-credstore|%d|%s|%d|%s", e.Version, e.KDF, e.Iterations, e.Salt))
+}
+
+func deriveKey(secret, salt []byte, iterations int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, string(secret), salt, iterations, keySize)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Secret 은 저장소를 열 secret 을 읽습니다.
+// keyFile 이 있으면 키 파일 내용을, 없으면 NAVER_STORE_PASSPHRASE 환경 변수를 사용합니다.
+// 둘 다 없으면 ErrNoSecret 을 반환하므로, 호출하는 쪽에서 암호를 입력받을 수 있습니다.
+func Secret(keyFile string) ([]byte, error) {
+	if keyFile != "" {
+		return ReadKeyFile(keyFile)
+	}
+	if v := os.Getenv(PassphraseEnv); v != "" {
+		return []byte(v), nil
+	}
+	return nil, ErrNoSecret
+}
+
+// ReadKeyFile 은 키 파일 내용을 읽습니다. 끝의 줄바꿈은 무시합니다.
+func ReadKeyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("키 파일 읽기 실패: %v", err)
+	}
+	key := []byte(strings.TrimRight(string(data), "\r\n"))
+	if len(key) < MinPassphrase {
+		return nil, fmt.Errorf("키 파일 %s 이(가) 너무 짧습니다 (%d바이트 이상 필요)", path, MinPassphrase)
+	}
+	return key, nil
+}
+
+// GenerateKeyFile 은 무작위 키를 path 에 새로 만들고 그 내용을 반환합니다. 파일이 이미 있으면 실패합니다.
+func GenerateKeyFile(path string) ([]byte, error) {
+	raw := make([]byte, keyFileSize)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	key := []byte(hex.EncodeToString(raw))
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, fmt.Errorf("키 파일 디렉토리 생성 실패: %v", err)
+		}
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("키 파일 생성 실패: %v", err)
+	}
+	if _, err := f.Write(append(key, '\n')); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("키 파일 생성 실패: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("키 파일 생성 실패: %v", err)
+	}
+	return key, nil
+}
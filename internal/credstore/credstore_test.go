@@ -0,0 +1,278 @@
+package credstore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"naverCafeCrawler/internal/session"
+)
+
+const testSecret = "correct horse battery"
+
+// 세션 하나를 저장한 저장소 파일을 만들고 경로를 반환
+func newTestStore(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	s, err := Open(path, []byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := session.FromHeader("NID_AUT=secret-aut; NID_SES=secret-ses")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Put(Entry{Name: "main", Cookies: sess.Netscape(), Cafes: []string{"12345"}})
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRoundTrip(t *testing.T) {
+	path := newTestStore(t)
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("파일 권한 = %o, want 600", perm)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, plain := range []string{"secret-aut", "secret-ses", "NID_AUT", "12345"} {
+		if strings.Contains(string(data), plain) {
+			t.Errorf("저장소 파일에 평문 %q 이(가) 있습니다", plain)
+		}
+	}
+
+	s, err := Open(path, []byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.Exists() {
+		t.Error("Exists() = false")
+	}
+	entry, ok := s.Get("main")
+	if !ok {
+		t.Fatalf("Get(main) 없음, Entries() = %v", s.Entries())
+	}
+	if len(entry.Cafes) != 1 || entry.Cafes[0] != "12345" {
+		t.Errorf("Cafes = %v, want [12345]", entry.Cafes)
+	}
+	sess, err := s.Session("main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sess.LoggedIn() {
+		t.Error("복호화한 세션이 로그인 상태가 아닙니다")
+	}
+	if _, err := s.Session("other"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Session(other) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestOpenTampered(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		tamper  func(env *envelope)
+		wantErr error  // errors.Is 로 비교
+		wantMsg string // wantErr 가 nil 이면 오류 메시지에 포함될 문구
+	}{
+		{"틀린 암호", "wrong passphrase", nil, ErrWrongPassphrase, ""},
+		{"salt 변조", testSecret, func(env *envelope) { env.Salt = flipHex(env.Salt) }, ErrWrongPassphrase, ""},
+		{"반복 횟수 변조", testSecret, func(env *envelope) { env.Iterations = 1000 }, ErrWrongPassphrase, ""},
+		{"nonce 변조", testSecret, func(env *envelope) { env.Nonce = flipHex(env.Nonce) }, ErrWrongPassphrase, ""},
+		{"암호문 변조", testSecret, func(env *envelope) { env.Ciphertext = flipHex(env.Ciphertext) }, ErrWrongPassphrase, ""},
+		{"암호문 잘림", testSecret, func(env *envelope) { env.Ciphertext = env.Ciphertext[:len(env.Ciphertext)-2] }, ErrWrongPassphrase, ""},
+		{"버전 변조", testSecret, func(env *envelope) { env.Version = 2 }, nil, "지원하지 않는 형식"},
+		{"KDF 변조", testSecret, func(env *envelope) { env.KDF = "none" }, nil, "지원하지 않는 형식"},
+		{"nonce 길이", testSecret, func(env *envelope) { env.Nonce = "00" }, nil, "잘못된 파일"},
+		{"hex 가 아닌 salt", testSecret, func(env *envelope) { env.Salt = "zz" }, nil, "잘못된 파일"},
+	}
+	original := newTestStore(t)
+	data, err := os.ReadFile(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "credentials.enc")
+			var env envelope
+			if err := json.Unmarshal(data, &env); err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(&env)
+			}
+			tampered, err := json.Marshal(env)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, tampered, 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = Open(path, []byte(tt.secret))
+			if err == nil {
+				t.Fatal("Open() error = nil")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("Open() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantMsg != "" && !strings.Contains(err.Error(), tt.wantMsg) {
+				t.Errorf("Open() error = %v, want %q 포함", err, tt.wantMsg)
+			}
+		})
+	}
+}
+
+// 키는 맞지만 머리 부분(인증 데이터)이 암호화할 때와 다르면 열지 않음
+func TestOpenAdditionalDataMismatch(t *testing.T) {
+	path := newTestStore(t)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var env envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	salt, _ := hex.DecodeString(env.Salt)
+	nonce, _ := hex.DecodeString(env.Nonce)
+	key, err := deriveKey([]byte(testSecret), salt, env.Iterations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := aead.Open(nil, nonce, mustHex(t, env.Ciphertext), env.additionalData())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 같은 키로 다른 머리 부분을 인증 데이터로 다시 암호화
+	other := env
+	other.Salt = env.Salt + "00"
+	env.Ciphertext = hex.EncodeToString(aead.Seal(nil, nonce, plain, other.additionalData()))
+	tampered, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, tampered, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(path, []byte(testSecret)); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("Open() error = %v, want ErrWrongPassphrase", err)
+	}
+}
+
+func TestRekey(t *testing.T) {
+	path := newTestStore(t)
+	s, err := Open(path, []byte(testSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	const newSecret = "another long secret"
+	if err := s.Rekey([]byte(newSecret)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Open(path, []byte(testSecret)); !errors.Is(err, ErrWrongPassphrase) {
+		t.Errorf("이전 암호로 Open() error = %v, want ErrWrongPassphrase", err)
+	}
+	s, err = Open(path, []byte(newSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Get("main"); !ok {
+		t.Error("새 키로 연 저장소에 세션이 없습니다")
+	}
+}
+
+func TestSecret(t *testing.T) {
+	dir := t.TempDir()
+	short := filepath.Join(dir, "short.key")
+	if err := os.WriteFile(short, []byte("abc\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	generated := filepath.Join(dir, "sub", "generated.key")
+	key, err := GenerateKeyFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hex.DecodeString(string(key)); err != nil || len(key) != 2*keyFileSize {
+		t.Errorf("GenerateKeyFile() = %q, want hex %d자", key, 2*keyFileSize)
+	}
+	if _, err := GenerateKeyFile(generated); err == nil {
+		t.Error("이미 있는 키 파일을 덮어썼습니다")
+	}
+
+	tests := []struct {
+		name    string
+		keyFile string
+		env     string
+		want    string
+		wantErr error
+	}{
+		{"키 파일", generated, "env passphrase", string(key), nil},
+		{"환경 변수", "", "env passphrase", "env passphrase", nil},
+		{"없음", "", "", "", ErrNoSecret},
+		{"짧은 키 파일", short, "", "", nil},
+		{"없는 키 파일", filepath.Join(dir, "missing.key"), "", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(PassphraseEnv, tt.env)
+			got, err := Secret(tt.keyFile)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("Secret() = %q, want error", got)
+				}
+				if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+					t.Errorf("Secret() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Secret() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// hex 문자열의 첫 글자를 바꿈
+func flipHex(s string) string {
+	b := []byte(s)
+	if b[0] == '0' {
+		b[0] = '1'
+	} else {
+		b[0] = '0'
+	}
+	return string(b)
+}
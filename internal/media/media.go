@@ -0,0 +1,167 @@
+// Package media 는 게시글 본문과 댓글의 이미지, GIF, 동영상, 첨부 파일을 찾아
+// 로컬 디렉토리에 내려받습니다. 파일은 내용의 SHA-256 해시로 이름을 지어
+// 같은 파일은 한 번만 저장합니다.
+package media
+
+import (
+	"html"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"naverCafeCrawler/internal/content"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Kind 는 미디어 종류입니다.
+type Kind string
+
+const (
+	KindImage Kind = "image"
+	KindGIF   Kind = "gif"
+	KindVideo Kind = "video"
+	KindFile  Kind = "file"
+)
+
+// Ref 는 본문에서 찾은 미디어 주소입니다.
+type Ref struct {
+	URL  string
+	Kind Kind
+}
+
+// File 은 미디어를 내려받은 결과입니다. 실패하면 Path 는 비어 있고 Error 에 이유가 남습니다.
+type File struct {
+	URL         string `json:"url"`
+	Kind        Kind   `json:"kind"`
+	Path        string `json:"path,omitempty"`
+	SHA256      string `json:"sha256,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Extract 는 HTML 에서 미디어 주소를 등장 순서대로 중복 없이 찾습니다.
+// 이미지(지연 로딩 주소 포함), 동영상으로 변환된 GIF, 동영상, 첨부 파일 링크를 찾습니다.
+func Extract(htmlContent string) []Ref {
+	if strings.TrimSpace(htmlContent) == "" {
+		return nil
+	}
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlContent))
+	if err != nil {
+		return nil
+	}
+
+	var refs []Ref
+	add := func(raw string, kind Kind) {
+		refs = append(refs, Ref{URL: raw, Kind: kind})
+	}
+
+	doc.Find("img, video, video source, a").Each(func(_ int, s *goquery.Selection) {
+		n := s.Get(0)
+		switch goquery.NodeName(s) {
+		case "img":
+			src := content.ImageSource(n)
+			kind := KindImage
+			if strings.EqualFold(path.Ext(urlPath(src)), ".gif") {
+				kind = KindGIF
+			}
+			add(src, kind)
+		case "video", "source":
+			// SmartEditor 는 GIF 를 mp4 동영상(_gifmp4)으로 변환해 넣음
+			kind := KindVideo
+			if s.Closest("._gifmp4, .se-gif, [data-gif-url]").Length() > 0 {
+				kind = KindGIF
+			}
+			if gif, ok := s.Attr("data-gif-url"); ok {
+				add(gif, KindGIF)
+			}
+			if src, ok := s.Attr("src"); ok {
+				add(src, kind)
+			}
+		case "a":
+			href, _ := s.Attr("href")
+			_, download := s.Attr("download")
+			if download || s.HasClass("se-file-save-button") || s.HasClass("se-file-save") {
+				add(href, KindFile)
+			}
+		}
+	})
+	return Unique(refs)
+}
+
+// Unique 는 주소를 정리하고 http(s) 가 아니거나 중복된 항목을 뺀 목록을 반환합니다.
+func Unique(refs []Ref) []Ref {
+	var out []Ref
+	seen := make(map[string]bool)
+	for _, ref := range refs {
+		u := normalizeURL(ref.URL)
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		out = append(out, Ref{URL: u, Kind: ref.Kind})
+	}
+	return out
+}
+
+// HTML 속성 (이름=, 따옴표로 감싼 값)
+var attrRe = regexp.MustCompile(`(\s[\w:-]+\s*=\s*)("[^"]*"|'[^']*')`)
+
+// Rewrite 는 HTML 속성 값 중 내려받은 미디어 주소와 정확히 같은 것을 로컬 경로로 바꿉니다.
+func Rewrite(htmlContent string, files []File) string {
+	local := make(map[string]string)
+	for _, f := range files {
+		if f.Path != "" {
+			local[f.URL] = toSlash(f.Path)
+		}
+	}
+	if len(local) == 0 {
+		return htmlContent
+	}
+	return attrRe.ReplaceAllStringFunc(htmlContent, func(m string) string {
+		sub := attrRe.FindStringSubmatch(m)
+		quoted := sub[2]
+		value := html.UnescapeString(quoted[1 : len(quoted)-1])
+		p, ok := local[normalizeURL(value)]
+		if !ok {
+			return m
+		}
+		return sub[1] + quoted[:1] + html.EscapeString(p) + quoted[:1]
+	})
+}
+
+// LocalPath 는 files 중 rawURL 을 내려받은 로컬 경로를 반환합니다. 없으면 rawURL 그대로입니다.
+func LocalPath(rawURL string, files []File) string {
+	u := normalizeURL(rawURL)
+	for _, f := range files {
+		if f.URL == u && f.Path != "" {
+			return toSlash(f.Path)
+		}
+	}
+	return rawURL
+}
+
+func normalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if strings.HasPrefix(raw, "//") {
+		raw = "https:" + raw
+	}
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return ""
+	}
+	return raw
+}
+
+func urlPath(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	return u.Path
+}
+
+func toSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
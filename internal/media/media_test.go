@@ -0,0 +1,234 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// 테스트 서버에서 주소별 응답을 돌려주고 요청 수를 세는 FetchFunc
+func newTestServer(t *testing.T) (*httptest.Server, FetchFunc, *int32) {
+	t.Helper()
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/photo.png", "/same-a", "/same-b":
+			w.Header().Set("Content-Type", "image/jpeg")
+			fmt.Fprint(w, "same image bytes")
+		case "/other":
+			w.Header().Set("Content-Type", "image/webp")
+			fmt.Fprint(w, "other image bytes")
+		case "/broken":
+			// 본문이 Content-Length 보다 짧게 끊김
+			w.Header().Set("Content-Length", "1000")
+			fmt.Fprint(w, "partial")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	fetch := func(ctx context.Context, url string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := srv.Client().Do(req)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("HTTP 오류: %d", resp.StatusCode)
+		}
+		return resp, nil
+	}
+	return srv, fetch, &requests
+}
+
+func TestDownloadDedup(t *testing.T) {
+	srv, fetch, requests := newTestServer(t)
+	dir := t.TempDir()
+	s := NewStore(dir)
+	ctx := context.Background()
+
+	first, err := s.Download(ctx, fetch, Ref{URL: srv.URL + "/same-a?type=w800", Kind: KindImage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := s.Download(ctx, fetch, Ref{URL: srv.URL + "/same-a?type=w800", Kind: KindImage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(requests); n != 1 {
+		t.Errorf("같은 주소를 %d번 요청했습니다, want 1", n)
+	}
+	if again != first {
+		t.Errorf("두 번째 Download() = %+v, want %+v", again, first)
+	}
+
+	// 주소가 달라도 내용이 같으면 같은 파일
+	other, err := s.Download(ctx, fetch, Ref{URL: srv.URL + "/same-b", Kind: KindImage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.Path != first.Path || other.SHA256 != first.SHA256 {
+		t.Errorf("같은 내용의 파일 경로 = %s, want %s", other.Path, first.Path)
+	}
+	want := filepath.Join(dir, first.SHA256[:2], first.SHA256+".jpg")
+	if first.Path != want {
+		t.Errorf("Path = %s, want %s", first.Path, want)
+	}
+	if first.Size != int64(len("same image bytes")) || first.ContentType != "image/jpeg" {
+		t.Errorf("Size, ContentType = %d, %q", first.Size, first.ContentType)
+	}
+
+	// 새 Store 는 색인에서 이미 내려받은 주소를 찾음
+	reloaded, err := NewStore(dir).Download(ctx, fetch, Ref{URL: srv.URL + "/same-a?type=w800", Kind: KindImage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(requests); n != 2 {
+		t.Errorf("색인에 있는 주소를 다시 요청했습니다 (요청 %d번)", n)
+	}
+	if reloaded.Path != first.Path {
+		t.Errorf("색인에서 읽은 Path = %s, want %s", reloaded.Path, first.Path)
+	}
+
+	// 파일이 지워졌으면 다시 내려받음
+	if err := os.Remove(first.Path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Download(ctx, fetch, Ref{URL: srv.URL + "/same-a?type=w800", Kind: KindImage}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(first.Path); err != nil {
+		t.Errorf("지워진 파일을 다시 내려받지 않았습니다: %v", err)
+	}
+}
+
+func TestDownloadFailure(t *testing.T) {
+	srv, fetch, _ := newTestServer(t)
+	dir := t.TempDir()
+	s := NewStore(dir)
+
+	refs := []Ref{
+		{URL: srv.URL + "/broken", Kind: KindImage},
+		{URL: srv.URL + "/missing.jpg", Kind: KindImage},
+		{URL: srv.URL + "/other", Kind: KindImage},
+	}
+	files, err := s.DownloadAll(context.Background(), fetch, refs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != len(refs) {
+		t.Fatalf("DownloadAll() 결과 %d개, want %d개", len(files), len(refs))
+	}
+	for i, f := range files[:2] {
+		if f.Error == "" || f.Path != "" || f.URL != refs[i].URL {
+			t.Errorf("실패한 파일 %d = %+v, want Error 만 있음", i, f)
+		}
+	}
+	if files[2].Error != "" || !strings.HasSuffix(files[2].Path, ".webp") {
+		t.Errorf("성공한 파일 = %+v", files[2])
+	}
+
+	tmps, err := filepath.Glob(filepath.Join(dir, "*.tmp"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmps) != 0 {
+		t.Errorf("임시 파일이 남아 있습니다: %v", tmps)
+	}
+	index, err := os.ReadFile(filepath.Join(dir, indexFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(index), "\n"); lines != 1 || strings.Contains(string(index), "/broken") {
+		t.Errorf("색인 = %q, want 성공한 파일 한 줄", index)
+	}
+}
+
+func TestExtension(t *testing.T) {
+	tests := []struct {
+		url         string
+		contentType string
+		want        string
+	}{
+		{"https://postfiles.pstatic.net/a/photo.JPG?type=w800", "image/png", ".jpg"},
+		{"https://postfiles.pstatic.net/a/photo.png?type=w800", "", ".png"},
+		{"https://postfiles.pstatic.net/a/photo?type=w800", "image/jpeg", ".jpg"},
+		{"https://postfiles.pstatic.net/a/photo?type=w800.gif", "image/webp", ".webp"},
+		{"https://mblogvideo-phinf.pstatic.net/a/video", "video/mp4; charset=binary", ".mp4"},
+		{"https://cafeattach.naver.net/a/download", "application/pdf", ".pdf"},
+		{"https://postfiles.pstatic.net/a/photo.verylongext", "image/gif", ".gif"},
+		{"https://postfiles.pstatic.net/a/photo", "", ""},
+	}
+	for _, tt := range tests {
+		if got := extension(tt.url, tt.contentType); got != tt.want {
+			t.Errorf("extension(%q, %q) = %q, want %q", tt.url, tt.contentType, got, tt.want)
+		}
+	}
+
+	// 쿼리 문자열이 붙은 주소도 경로의 확장자로 저장
+	srv, fetch, _ := newTestServer(t)
+	f, err := NewStore(t.TempDir()).Download(context.Background(), fetch, Ref{URL: srv.URL + "/photo.png?type=w800", Kind: KindImage})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Ext(f.Path) != ".png" {
+		t.Errorf("Path = %s, want .png", f.Path)
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	files := []File{
+		{URL: "https://postfiles.pstatic.net/a.jpg?type=w800&x=1", Path: `media\ab\abcd.jpg`},
+		{URL: "https://postfiles.pstatic.net/b.gif", Path: "media/cd/cdef.gif"},
+		{URL: "https://postfiles.pstatic.net/failed.jpg", Error: "HTTP 오류: 404"},
+	}
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "src 와 data-lazy-src",
+			html: `<img src="https://postfiles.pstatic.net/a.jpg?type=w800&amp;x=1" data-lazy-src='https://postfiles.pstatic.net/a.jpg?type=w800&x=1'>`,
+			want: `<img src="media/ab/abcd.jpg" data-lazy-src='media/ab/abcd.jpg'>`,
+		},
+		{
+			name: "스킴 생략 주소",
+			html: `<video data-gif-url="//postfiles.pstatic.net/b.gif" src="https://postfiles.pstatic.net/b.mp4"></video>`,
+			want: `<video data-gif-url="media/cd/cdef.gif" src="https://postfiles.pstatic.net/b.mp4"></video>`,
+		},
+		{
+			name: "내려받지 못한 파일과 본문 텍스트는 그대로",
+			html: `<p>https://postfiles.pstatic.net/b.gif</p><img src="https://postfiles.pstatic.net/failed.jpg">`,
+			want: `<p>https://postfiles.pstatic.net/b.gif</p><img src="https://postfiles.pstatic.net/failed.jpg">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Rewrite(tt.html, files); got != tt.want {
+				t.Errorf("Rewrite()\n got: %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+
+	if got := Rewrite(tests[0].html, nil); got != tests[0].html {
+		t.Errorf("내려받은 파일이 없으면 그대로여야 합니다: %s", got)
+	}
+	if got := LocalPath("https://postfiles.pstatic.net/a.jpg?type=w800&x=1", files); got != "media/ab/abcd.jpg" {
+		t.Errorf("LocalPath() = %s, want media/ab/abcd.jpg", got)
+	}
+	if got := LocalPath("https://postfiles.pstatic.net/failed.jpg", files); got != "https://postfiles.pstatic.net/failed.jpg" {
+		t.Errorf("LocalPath(실패한 파일) = %s, want 원래 주소", got)
+	}
+}
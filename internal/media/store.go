@@ -0,0 +1,196 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"naverCafeCrawler/internal/output"
+)
+
+// 색인 파일 이름. 한 줄에 내려받은 파일 하나(File)를 JSON 으로 기록합니다.
+const indexFilename = "index.jsonl"
+
+// FetchFunc 는 주소의 응답을 가져오는 함수입니다. 크롤러의 속도 제한과 재시도를 그대로 사용합니다.
+type FetchFunc func(ctx context.Context, url string) (*http.Response, error)
+
+// Store 는 미디어 파일을 dir 아래 <해시 앞 두 글자>/<해시>.<확장자> 로 저장합니다.
+// 이미 내려받은 주소는 색인에서 찾아 다시 요청하지 않고,
+// 주소가 달라도 내용이 같으면 같은 파일을 가리킵니다.
+// 색인은 기록할 때마다 파일 끝에 바로 추가하므로 중간에 중단되어도 유지됩니다.
+type Store struct {
+	dir string
+
+	once    sync.Once
+	loadErr error
+
+	mu    sync.Mutex
+	index map[string]File
+	log   *os.File
+}
+
+// NewStore 는 dir 에 미디어를 저장하는 Store 를 생성합니다.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// 색인 읽기
+func (s *Store) load() error {
+	s.once.Do(func() {
+		s.index = make(map[string]File)
+		if err := os.MkdirAll(s.dir, 0755); err != nil {
+			s.loadErr = fmt.Errorf("미디어 디렉토리 생성 실패: %v", err)
+			return
+		}
+		filename := filepath.Join(s.dir, indexFilename)
+		err := output.ReadJSONL(filename, func(line []byte) error {
+			var f File
+			if err := json.Unmarshal(line, &f); err == nil && f.Path != "" {
+				s.index[f.URL] = f
+			}
+			return nil
+		})
+		if err != nil {
+			s.loadErr = fmt.Errorf("미디어 색인 읽기 실패: %v", err)
+			return
+		}
+		s.log, s.loadErr = os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	})
+	return s.loadErr
+}
+
+// DownloadAll 은 refs 를 차례로 내려받습니다. 개별 파일의 실패는 File.Error 에 기록하고
+// 계속 진행하며, ctx 가 취소된 경우에만 오류를 반환합니다.
+func (s *Store) DownloadAll(ctx context.Context, fetch FetchFunc, refs []Ref) ([]File, error) {
+	files := make([]File, 0, len(refs))
+	for _, ref := range refs {
+		f, err := s.Download(ctx, fetch, ref)
+		if err != nil {
+			if ctx.Err() != nil {
+				return files, ctx.Err()
+			}
+			f = File{URL: ref.URL, Kind: ref.Kind, Error: err.Error()}
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// Download 는 ref 를 내려받아 저장하고 결과를 반환합니다.
+func (s *Store) Download(ctx context.Context, fetch FetchFunc, ref Ref) (File, error) {
+	if err := s.load(); err != nil {
+		return File{}, err
+	}
+	if f, ok := s.lookup(ref.URL); ok {
+		return f, nil
+	}
+
+	resp, err := fetch(ctx, ref.URL)
+	if err != nil {
+		return File{}, err
+	}
+	defer resp.Body.Close()
+
+	tmp, err := os.CreateTemp(s.dir, "download-*.tmp")
+	if err != nil {
+		return File{}, fmt.Errorf("임시 파일 생성 실패: %v", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return File{}, fmt.Errorf("미디어 저장 실패: %v", err)
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	contentType := resp.Header.Get("Content-Type")
+	final := filepath.Join(s.dir, sum[:2], sum+extension(ref.URL, contentType))
+	if _, err := os.Stat(final); os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(final), 0755); err != nil {
+			return File{}, fmt.Errorf("디렉토리 생성 실패: %v", err)
+		}
+		if err := os.Rename(tmp.Name(), final); err != nil {
+			return File{}, fmt.Errorf("미디어 저장 실패: %v", err)
+		}
+	}
+
+	f := File{
+		URL:         ref.URL,
+		Kind:        ref.Kind,
+		Path:        final,
+		SHA256:      sum,
+		Size:        size,
+		ContentType: contentType,
+	}
+	return f, s.record(f)
+}
+
+func (s *Store) lookup(rawURL string) (File, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, ok := s.index[rawURL]
+	if !ok {
+		return File{}, false
+	}
+	if _, err := os.Stat(f.Path); err != nil {
+		// 파일이 지워졌으면 다시 내려받음
+		delete(s.index, rawURL)
+		return File{}, false
+	}
+	return f, true
+}
+
+// 색인에 추가
+func (s *Store) record(f File) error {
+	line, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index[f.URL] = f
+	if _, err := s.log.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("미디어 색인 기록 실패: %v", err)
+	}
+	return nil
+}
+
+// 주소의 확장자, 없으면 Content-Type 으로 확장자 결정
+func extension(rawURL, contentType string) string {
+	ext := strings.ToLower(path.Ext(urlPath(rawURL)))
+	if ext != "" && len(ext) <= 6 {
+		return ext
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch mediaType {
+		case "image/jpeg":
+			return ".jpg"
+		case "image/png":
+			return ".png"
+		case "image/gif":
+			return ".gif"
+		case "image/webp":
+			return ".webp"
+		case "video/mp4":
+			return ".mp4"
+		}
+		if exts, err := mime.ExtensionsByType(mediaType); err == nil && len(exts) > 0 {
+			return exts[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,251 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+var (
+	csvArticleHeader = []string{"source", "board", "id", "title", "writer", "write_date",
+		"comment_count", "read_count", "like_count", "url", "content", "comment_count_mismatch",
+		"content_text", "content_markdown", "deleted"}
+	csvCommentHeader = []string{"source", "board", "article_id", "id", "writer", "write_date",
+		"like_count", "content", "parent_id", "depth", "deleted", "secret", "sticker", "image"}
+)
+
+// CSVSink 는 게시글과 댓글을 각각 별도의 CSV 파일(표)로 기록합니다.
+// 파일이 이미 있으면 머리글 없이 이어서 기록합니다.
+// 중단된 크롤링을 이어서 할 때는 Open 전에 TruncateTo 로 마지막 동기화 위치 뒤의 불완전한 행을 잘라냅니다.
+type CSVSink struct {
+	articlesFilename string
+	commentsFilename string
+
+	mu           sync.Mutex
+	articlesFile *os.File
+	commentsFile *os.File
+	articles     *csv.Writer
+	comments     *csv.Writer
+	offsets      map[string]int64 // 파일 이름별 마지막 동기화 위치
+}
+
+// NewCSVSink 는 게시글을 articlesFilename, 댓글을 commentsFilename 에 기록하는 CSVSink 를 생성합니다.
+func NewCSVSink(articlesFilename, commentsFilename string) *CSVSink {
+	return &CSVSink{articlesFilename: articlesFilename, commentsFilename: commentsFilename}
+}
+
+// Open 은 두 CSV 파일을 추가 모드로 엽니다.
+func (s *CSVSink) Open() error {
+	var err error
+	s.articlesFile, s.articles, err = openCSV(s.articlesFilename, csvArticleHeader)
+	if err != nil {
+		return err
+	}
+	s.commentsFile, s.comments, err = openCSV(s.commentsFilename, csvCommentHeader)
+	if err != nil {
+		s.articlesFile.Close()
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offsets = make(map[string]int64, 2)
+	if err := s.syncLocked(); err != nil {
+		s.articlesFile.Close()
+		s.commentsFile.Close()
+		return err
+	}
+	return nil
+}
+
+func openCSV(filename string, header []string) (*os.File, *csv.Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return nil, nil, fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+	file, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, nil, fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	w := csv.NewWriter(file)
+	if info.Size() == 0 {
+		// 엑셀에서 한글이 깨지지 않도록 UTF-8 BOM 추가
+		if _, err := file.WriteString("\ufeff"); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		if err := w.Write(header); err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+	}
+	return file, w, nil
+}
+
+// WriteArticle 은 게시글 한 행을 기록합니다.
+func (s *CSVSink) WriteArticle(a Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeArticleLocked(a)
+}
+
+// 게시글과 댓글을 다른 고루틴의 Sync 가 끼어들지 않도록 한 번에 기록
+func (s *CSVSink) writeArticleWithComments(a Article, comments []Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.writeArticleLocked(a); err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if err := s.writeCommentLocked(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *CSVSink) writeArticleLocked(a Article) error {
+	return s.articles.Write([]string{
+		a.Source, a.Board, a.ID, a.Title, a.Writer, a.WriteDate,
+		strconv.Itoa(a.CommentCount), strconv.Itoa(a.ReadCount), strconv.Itoa(a.LikeCount),
+		a.URL, a.Content, strconv.FormatBool(a.CommentCountMismatch),
+		a.ContentText, a.ContentMarkdown, strconv.FormatBool(a.Deleted),
+	})
+}
+
+// WriteComment 는 댓글 한 행을 기록합니다.
+func (s *CSVSink) WriteComment(c Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeCommentLocked(c)
+}
+
+func (s *CSVSink) writeCommentLocked(c Comment) error {
+	return s.comments.Write([]string{
+		c.Source, c.Board, c.ArticleID, c.ID, c.Writer, c.WriteDate,
+		strconv.Itoa(c.LikeCount), c.Content,
+		c.ParentID, strconv.Itoa(c.Depth), strconv.FormatBool(c.Deleted), strconv.FormatBool(c.Secret),
+		c.Sticker, c.Image,
+	})
+}
+
+// Sync 는 버퍼를 비우고 두 파일을 디스크에 동기화합니다.
+func (s *CSVSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.syncLocked()
+}
+
+func (s *CSVSink) syncLocked() error {
+	for _, pair := range []struct {
+		w    *csv.Writer
+		file *os.File
+	}{{s.articles, s.articlesFile}, {s.comments, s.commentsFile}} {
+		pair.w.Flush()
+		if err := pair.w.Error(); err != nil {
+			return err
+		}
+		if err := pair.file.Sync(); err != nil {
+			return err
+		}
+		info, err := pair.file.Stat()
+		if err != nil {
+			return err
+		}
+		s.offsets[filepath.Base(pair.file.Name())] = info.Size()
+	}
+	return nil
+}
+
+// Offsets 는 두 파일의 마지막 동기화 위치(바이트)를 파일 이름별로 반환합니다.
+// 이 위치까지는 완전한 행만 기록되어 있습니다.
+func (s *CSVSink) Offsets() map[string]int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	offsets := make(map[string]int64, len(s.offsets))
+	for name, offset := range s.offsets {
+		offsets[name] = offset
+	}
+	return offsets
+}
+
+// TruncateTo 는 Offsets 로 기록해 둔 위치 뒤에 남은 (중단으로 불완전할 수 있는) 데이터를 잘라냅니다.
+// Open 전에 호출해야 하며, 위치가 없는 파일은 그대로 둡니다.
+func (s *CSVSink) TruncateTo(offsets map[string]int64) error {
+	for _, filename := range []string{s.articlesFilename, s.commentsFilename} {
+		offset, ok := offsets[filepath.Base(filename)]
+		if !ok {
+			continue
+		}
+		info, err := os.Stat(filename)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("파일 확인 실패: %v", err)
+		}
+		if info.Size() > offset {
+			if err := os.Truncate(filename, offset); err != nil {
+				return fmt.Errorf("불완전한 행 잘라내기 실패: %v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// Close 는 남은 데이터를 기록하고 파일을 닫습니다.
+func (s *CSVSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.articlesFile == nil {
+		return nil
+	}
+	err := s.syncLocked()
+	if closeErr := s.articlesFile.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := s.commentsFile.Close(); err == nil {
+		err = closeErr
+	}
+	s.articlesFile, s.commentsFile = nil, nil
+	return err
+}
+
+// ArticleIDs 는 게시글 CSV 에 기록된 ID 를 반환합니다.
+func (s *CSVSink) ArticleIDs() (map[string]bool, error) {
+	ids := make(map[string]bool)
+	file, err := os.Open(s.articlesFilename)
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+
+	r := csv.NewReader(file)
+	r.FieldsPerRecord = -1
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if err == io.EOF {
+			return ids, nil
+		}
+		if err != nil {
+			// 중간에 끊긴 마지막 행은 무시
+			return ids, nil
+		}
+		if first || len(record) < 3 {
+			continue
+		}
+		ids[record[2]] = true
+	}
+}
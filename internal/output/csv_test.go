@@ -0,0 +1,124 @@
+package output
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCSVSinkTruncateTo(t *testing.T) {
+	tests := []struct {
+		name    string
+		partial string // 마지막 동기화 뒤에 중단되며 남은 데이터
+	}{
+		{"따옴표 안에서 끊긴 행", "cafe,1/2,3,\"제목\n두 번째 줄"},
+		{"개행으로 끝난 완전한 줄처럼 보이는 행", "cafe,1/2,3,\"본문 첫 줄\n"},
+		{"남은 데이터 없음", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			articles := filepath.Join(dir, "a_articles.csv")
+			comments := filepath.Join(dir, "a_comments.csv")
+
+			sink := NewCSVSink(articles, comments)
+			if err := sink.Open(); err != nil {
+				t.Fatal(err)
+			}
+			article := Article{Source: "cafe", Board: "1/2", ID: "1", Title: "여러 줄\n제목", Content: "<p>\"따옴표\"\n본문</p>"}
+			comment := Comment{Source: "cafe", Board: "1/2", ArticleID: "1", ID: "10", Content: "댓글\n두 줄"}
+			if err := WriteArticleWithComments(sink, article, []Comment{comment}); err != nil {
+				t.Fatal(err)
+			}
+			if err := sink.Sync(); err != nil {
+				t.Fatal(err)
+			}
+			offsets := sink.Offsets()
+			if err := sink.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			appendString(t, articles, tt.partial)
+			appendString(t, comments, tt.partial)
+
+			resumed := NewCSVSink(articles, comments)
+			if err := resumed.TruncateTo(offsets); err != nil {
+				t.Fatal(err)
+			}
+			if err := resumed.Open(); err != nil {
+				t.Fatal(err)
+			}
+			article.ID = "2"
+			if err := resumed.WriteArticle(article); err != nil {
+				t.Fatal(err)
+			}
+			if err := resumed.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			rows := readCSV(t, articles)
+			if len(rows) != 3 {
+				t.Fatalf("게시글 행 %d개, want 3 (머리글 + 2): %q", len(rows), rows)
+			}
+			for i, id := range []string{"1", "2"} {
+				if rows[i+1][2] != id || rows[i+1][3] != article.Title {
+					t.Errorf("행 %d = %q, want ID %s 제목 %q", i+1, rows[i+1][:4], id, article.Title)
+				}
+			}
+			if rows := readCSV(t, comments); len(rows) != 2 || rows[1][7] != comment.Content {
+				t.Errorf("댓글 행 = %q, want 머리글 + 댓글 1개", rows)
+			}
+			ids, err := resumed.ArticleIDs()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != 2 || !ids["1"] || !ids["2"] {
+				t.Errorf("ArticleIDs() = %v, want 1, 2", ids)
+			}
+		})
+	}
+}
+
+func TestCSVSinkTruncateToMissingOffset(t *testing.T) {
+	dir := t.TempDir()
+	articles := filepath.Join(dir, "a_articles.csv")
+	appendString(t, articles, "source,board,id\n")
+	sink := NewCSVSink(articles, filepath.Join(dir, "a_comments.csv"))
+	if err := sink.TruncateTo(map[string]int64{"other.csv": 0}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(articles)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "source,board,id\n" {
+		t.Errorf("위치가 없는 파일이 바뀌었습니다: %q", data)
+	}
+}
+
+func appendString(t *testing.T, filename, s string) {
+	t.Helper()
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(s); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readCSV(t *testing.T, filename string) [][]string {
+	t.Helper()
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(data), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("%s 파싱 실패: %v", filename, err)
+	}
+	return rows
+}
@@ -0,0 +1,156 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONSink 는 게시글을 하나의 JSON 배열 파일로 기록합니다.
+// 기록 중에는 임시 파일에 쓰고 Close 에서 배열을 닫은 뒤 이름을 바꾸므로,
+// 중간에 중단되어도 기존 파일은 항상 올바른 JSON 으로 남습니다.
+// 이전 실행의 파일이 있으면 그 내용을 유지한 채 이어서 기록합니다.
+type JSONSink struct {
+	filename string
+
+	mu    sync.Mutex
+	file  *os.File
+	buf   *bufio.Writer
+	count int
+}
+
+// NewJSONSink 는 filename 에 기록하는 JSONSink 를 생성합니다.
+func NewJSONSink(filename string) *JSONSink {
+	return &JSONSink{filename: filename}
+}
+
+func (s *JSONSink) tmpFilename() string {
+	return s.filename + ".tmp"
+}
+
+// Open 은 임시 파일을 만들고 기존 결과가 있으면 먼저 옮겨 적습니다.
+func (s *JSONSink) Open() error {
+	if err := os.MkdirAll(filepath.Dir(s.filename), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+
+	existing, err := s.readExisting()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(s.tmpFilename())
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	s.file = file
+	s.buf = bufio.NewWriter(file)
+	if _, err := s.buf.WriteString("["); err != nil {
+		return err
+	}
+	for _, raw := range existing {
+		if err := s.writeRaw(raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *JSONSink) readExisting() ([]json.RawMessage, error) {
+	data, err := os.ReadFile(s.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("기존 결과 읽기 실패: %v", err)
+	}
+	var existing []json.RawMessage
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return nil, fmt.Errorf("기존 결과 파싱 실패: %v", err)
+	}
+	return existing, nil
+}
+
+func (s *JSONSink) writeRaw(raw []byte) error {
+	sep := ",\n  "
+	if s.count == 0 {
+		sep = "\n  "
+	}
+	if _, err := s.buf.WriteString(sep); err != nil {
+		return err
+	}
+	if _, err := s.buf.Write(raw); err != nil {
+		return err
+	}
+	s.count++
+	return nil
+}
+
+// WriteArticle 은 게시글(댓글 포함)을 배열에 추가합니다.
+func (s *JSONSink) WriteArticle(a Article) error {
+	raw, err := json.MarshalIndent(jsonRecord(a), "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("JSON 변환 실패: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeRaw(raw)
+}
+
+// WriteComment 는 아무것도 하지 않습니다. 댓글은 게시글 레코드에 포함됩니다.
+func (s *JSONSink) WriteComment(c Comment) error {
+	return nil
+}
+
+// Close 는 배열을 닫고 임시 파일을 최종 파일로 바꿉니다.
+func (s *JSONSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+
+	tail := "\n]\n"
+	if s.count == 0 {
+		tail = "]\n"
+	}
+	_, err := s.buf.WriteString(tail)
+	if err == nil {
+		err = s.buf.Flush()
+	}
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file = nil
+	if err != nil {
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
+
+	if err := os.Rename(s.tmpFilename(), s.filename); err != nil {
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// ArticleIDs 는 파일에 기록된 게시글 ID 를 반환합니다.
+func (s *JSONSink) ArticleIDs() (map[string]bool, error) {
+	existing, err := s.readExisting()
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]bool, len(existing))
+	for _, raw := range existing {
+		if id, ok := recordID(raw); ok {
+			ids[id] = true
+		}
+	}
+	return ids, nil
+}
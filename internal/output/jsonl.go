@@ -0,0 +1,173 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// JSONLSink 는 게시글을 한 줄에 하나씩 JSON 으로 파일 끝에 추가합니다.
+// 이전 실행의 파일이 있으면 이어서 기록합니다.
+type JSONLSink struct {
+	filename string
+
+	mu   sync.Mutex
+	file *os.File
+	buf  *bufio.Writer
+	enc  *json.Encoder
+}
+
+// NewJSONLSink 는 filename 에 기록하는 JSONLSink 를 생성합니다.
+func NewJSONLSink(filename string) *JSONLSink {
+	return &JSONLSink{filename: filename}
+}
+
+// Open 은 파일을 추가 모드로 엽니다. 파일이 없으면 새로 만들고,
+// 이전 실행이 중간에 끊겨 마지막 줄이 불완전하면 그 줄을 잘라냅니다.
+func (s *JSONLSink) Open() error {
+	if err := os.MkdirAll(filepath.Dir(s.filename), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+	if err := truncatePartialLine(s.filename); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(s.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %v", err)
+	}
+
+	s.file = file
+	s.buf = bufio.NewWriter(file)
+	s.enc = json.NewEncoder(s.buf)
+	s.enc.SetEscapeHTML(false)
+	return nil
+}
+
+// WriteArticle 은 게시글(댓글 포함)을 한 줄의 JSON 으로 기록합니다.
+func (s *JSONLSink) WriteArticle(a Article) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.enc.Encode(jsonRecord(a)); err != nil {
+		return fmt.Errorf("JSON 기록 실패: %v", err)
+	}
+	return nil
+}
+
+// WriteComment 는 아무것도 하지 않습니다. 댓글은 게시글 레코드에 포함됩니다.
+func (s *JSONLSink) WriteComment(c Comment) error {
+	return nil
+}
+
+// Sync 는 버퍼를 비우고 파일을 디스크에 동기화(fsync)합니다.
+func (s *JSONLSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Close 는 남은 데이터를 기록하고 파일을 닫습니다.
+func (s *JSONLSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.Sync()
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	s.file = nil
+	return err
+}
+
+// ArticleIDs 는 파일에 기록된 게시글 ID 를 반환합니다.
+func (s *JSONLSink) ArticleIDs() (map[string]bool, error) {
+	ids := make(map[string]bool)
+	err := ReadJSONL(s.filename, func(line []byte) error {
+		if id, ok := recordID(line); ok {
+			ids[id] = true
+		}
+		return nil
+	})
+	return ids, err
+}
+
+// ReadJSONL 은 filename 의 각 줄을 fn 에 넘깁니다. 파일이 없으면 아무것도 하지 않습니다.
+// 불완전한 마지막 줄은 건너뜁니다.
+func ReadJSONL(filename string, fn func(line []byte) error) error {
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("파일 읽기 실패: %v", err)
+		}
+		if len(line) <= 1 {
+			continue
+		}
+		if err := fn(line); err != nil {
+			return err
+		}
+	}
+}
+
+// 마지막 개행 이후의 불완전한 데이터 제거
+func truncatePartialLine(filename string) error {
+	file, err := os.OpenFile(filename, os.O_RDWR, 0)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("파일 열기 실패: %v", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	// 끝에서부터 거슬러 올라가며 마지막 개행 위치를 찾음
+	const chunk = 4096
+	size := info.Size()
+	end := size
+	buf := make([]byte, chunk)
+	for end > 0 {
+		start := end - chunk
+		if start < 0 {
+			start = 0
+		}
+		n, err := file.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		for i := n - 1; i >= 0; i-- {
+			if buf[i] == '\n' {
+				keep := start + int64(i) + 1
+				if keep == size {
+					return nil
+				}
+				return file.Truncate(keep)
+			}
+		}
+		end = start
+	}
+	return file.Truncate(0)
+}
@@ -0,0 +1,123 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncatePartialLine(t *testing.T) {
+	long := strings.Repeat("x", 5000) // 한 번에 읽는 크기(4096)보다 긴 줄
+	tests := []struct {
+		name string
+		data string // "" 이면 파일 없음
+		want string
+	}{
+		{"파일 없음", "", ""},
+		{"완전한 줄", "{\"id\":1}\n{\"id\":2}\n", "{\"id\":1}\n{\"id\":2}\n"},
+		{"불완전한 마지막 줄", "{\"id\":1}\n{\"id\":2}\n{\"id\":", "{\"id\":1}\n{\"id\":2}\n"},
+		{"개행 없는 한 줄", "{\"id\":1", ""},
+		{"긴 불완전한 줄", "{\"id\":1}\n" + long, "{\"id\":1}\n"},
+		{"긴 완전한 줄 뒤 불완전한 줄", long + "\n{", long + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filename := filepath.Join(t.TempDir(), "full.jsonl")
+			if tt.data != "" {
+				if err := os.WriteFile(filename, []byte(tt.data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := truncatePartialLine(filename); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(filename)
+			if tt.data == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("파일을 만들었습니다: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("남은 내용 = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadJSONL(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "full.jsonl")
+	if err := ReadJSONL(filename, func([]byte) error {
+		t.Error("없는 파일의 줄을 읽었습니다")
+		return nil
+	}); err != nil {
+		t.Fatalf("없는 파일 ReadJSONL() error = %v", err)
+	}
+
+	if err := os.WriteFile(filename, []byte("{\"id\":1}\n\n{\"id\":2}\n{\"id\":3"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	if err := ReadJSONL(filename, func(line []byte) error {
+		lines = append(lines, string(line))
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	// 빈 줄과 불완전한 마지막 줄은 건너뜀
+	want := []string{"{\"id\":1}\n", "{\"id\":2}\n"}
+	if !reflect.DeepEqual(lines, want) {
+		t.Errorf("읽은 줄 = %q, want %q", lines, want)
+	}
+}
+
+func TestJSONLSinkResume(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "out", "full.jsonl")
+	write := func(ids ...string) {
+		t.Helper()
+		sink := NewJSONLSink(filename)
+		if err := sink.Open(); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range ids {
+			if err := sink.WriteArticle(Article{Source: "cafe", Board: "1/2", ID: id, Title: "제목 " + id}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("1", "2")
+	// 중단되며 남은 불완전한 줄은 다시 열 때 잘라내고 이어서 기록
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"source":"cafe","id":"3","ti`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	write("3")
+
+	ids, err := NewJSONLSink(filename).ArticleIDs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]bool{"1": true, "2": true, "3": true}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("ArticleIDs() = %v, want %v", ids, want)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"); len(lines) != 3 {
+		t.Errorf("줄 %d개, want 3개:\n%s", len(lines), data)
+	}
+}
@@ -0,0 +1,208 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Sink 는 크롤링 결과를 기록하는 출력 대상입니다.
+// 여러 고루틴에서 동시에 사용해도 안전해야 합니다.
+type Sink interface {
+	Open() error
+	WriteArticle(a Article) error
+	WriteComment(c Comment) error
+	Close() error
+}
+
+// Syncer 는 지금까지 기록한 내용을 디스크에 동기화할 수 있는 Sink 입니다.
+type Syncer interface {
+	Sync() error
+}
+
+// Resumable 은 이미 기록된 게시글 ID 를 돌려줄 수 있는 Sink 입니다.
+// 중단된 크롤링을 이어서 할 때 중복 기록을 피하는 데 사용합니다.
+type Resumable interface {
+	ArticleIDs() (map[string]bool, error)
+}
+
+// Positioner 는 마지막으로 디스크에 동기화한 위치를 파일별로 알려주는 Sink 입니다.
+// 한 행이 여러 줄일 수 있어(CSV 의 따옴표 안 개행) 줄 단위로 불완전한 행을 찾을 수 없는 형식은
+// 이 위치를 체크포인트에 기록해 두었다가, 이어서 기록하기 전(Open 전)에 TruncateTo 로 그 뒤를 잘라냅니다.
+type Positioner interface {
+	Offsets() map[string]int64
+	TruncateTo(offsets map[string]int64) error
+}
+
+// 게시글과 댓글을 한 번에 기록해 동기화 위치가 게시글과 댓글 사이에 오지 않도록 하는 Sink
+type batchWriter interface {
+	writeArticleWithComments(a Article, comments []Comment) error
+}
+
+// Article 은 Sink 에 기록할 게시글입니다.
+// JSON 계열 Sink 는 Record(댓글 포함 원본 구조체)를 그대로 직렬화하고,
+// 표 형식 Sink(CSV, SQLite)는 나머지 필드를 열로 사용합니다.
+type Article struct {
+	Source       string // "cafe" 또는 "blog"
+	Board        string // 카페는 "카페ID/게시판ID", 블로그는 블로그 ID
+	ID           string
+	Title        string
+	Writer       string
+	WriteDate    string
+	CommentCount int
+	ReadCount    int
+	LikeCount    int
+	URL          string
+	Content      string // 원본 본문 (카페는 HTML)
+	// 본문을 변환한 일반 텍스트와 Markdown
+	ContentText     string
+	ContentMarkdown string
+	// 수집한 댓글 수가 CommentCount 와 다르면 true
+	CommentCountMismatch bool
+	// 상세 정보를 가져올 때 삭제되어 목록 정보만 있는 게시글(묘비)이면 true
+	Deleted bool
+	Record  interface{}
+}
+
+// Comment 는 Sink 에 기록할 댓글입니다.
+// JSON 계열 Sink 는 댓글이 Article.Record 에 포함되어 있으므로 무시합니다.
+type Comment struct {
+	Source    string
+	Board     string
+	ArticleID string
+	ID        string
+	ParentID  string // 답글이면 부모 댓글 ID
+	Depth     int    // 최상위 댓글은 0
+	Writer    string
+	WriteDate string
+	LikeCount int
+	Content   string
+	Deleted   bool
+	Secret    bool
+	Sticker   string // 스티커 이미지 URL
+	Image     string // 첨부 이미지 URL
+}
+
+// Format 은 출력 형식입니다.
+type Format string
+
+const (
+	FormatJSON   Format = "json"
+	FormatJSONL  Format = "jsonl"
+	FormatCSV    Format = "csv"
+	FormatSQLite Format = "sqlite"
+)
+
+// Formats 는 지원하는 출력 형식 목록입니다.
+var Formats = []Format{FormatJSON, FormatJSONL, FormatCSV, FormatSQLite}
+
+// ParseFormat 은 문자열을 출력 형식으로 변환합니다.
+func ParseFormat(s string) (Format, error) {
+	f := Format(strings.ToLower(strings.TrimSpace(s)))
+	for _, known := range Formats {
+		if f == known {
+			return f, nil
+		}
+	}
+	return "", fmt.Errorf("지원하지 않는 출력 형식: %q (json, jsonl, csv, sqlite 중 선택)", s)
+}
+
+// New 는 base 경로(확장자 제외)에 기록하는 Sink 를 생성합니다.
+// 실제 파일명은 형식에 따라 base.json, base.jsonl, base_articles.csv / base_comments.csv, base.db 입니다.
+func New(format Format, base string) (Sink, error) {
+	switch format {
+	case FormatJSON:
+		return NewJSONSink(base + ".json"), nil
+	case FormatJSONL:
+		return NewJSONLSink(base + ".jsonl"), nil
+	case FormatCSV:
+		return NewCSVSink(base+"_articles.csv", base+"_comments.csv"), nil
+	case FormatSQLite:
+		return NewSQLiteSink(base + ".db"), nil
+	}
+	return nil, fmt.Errorf("지원하지 않는 출력 형식: %q", format)
+}
+
+// Path 는 New(format, base) 가 기록하는 대표 파일 경로입니다.
+func Path(format Format, base string) string {
+	switch format {
+	case FormatJSONL:
+		return base + ".jsonl"
+	case FormatCSV:
+		return base + "_articles.csv"
+	case FormatSQLite:
+		return base + ".db"
+	}
+	return base + ".json"
+}
+
+// WriteArticleWithComments 는 게시글과 댓글을 차례로 기록합니다.
+func WriteArticleWithComments(s Sink, a Article, comments []Comment) error {
+	if w, ok := s.(batchWriter); ok {
+		return w.writeArticleWithComments(a, comments)
+	}
+	if err := s.WriteArticle(a); err != nil {
+		return err
+	}
+	for _, c := range comments {
+		if err := s.WriteComment(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Sync 는 s 가 Syncer 이면 디스크에 동기화합니다.
+func Sync(s Sink) error {
+	if syncer, ok := s.(Syncer); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
+
+// SaveJSON 은 data 를 들여쓰기 된 JSON 파일로 저장합니다.
+// 임시 파일에 쓴 뒤 이름을 바꿔 중단되더라도 기존 파일이 깨지지 않도록 합니다.
+func SaveJSON(data interface{}, filename string) error {
+	if err := os.MkdirAll(filepath.Dir(filename), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("JSON 변환 실패: %v", err)
+	}
+
+	tmpFilename := filename + ".tmp"
+	if err := os.WriteFile(tmpFilename, jsonData, 0644); err != nil {
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmpFilename, filename); err != nil {
+		os.Remove(tmpFilename)
+		return fmt.Errorf("파일 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// 레코드의 "id" 필드를 문자열로 추출
+func recordID(raw []byte) (string, bool) {
+	var rec struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(raw, &rec); err != nil || len(rec.ID) == 0 {
+		return "", false
+	}
+	var s string
+	if err := json.Unmarshal(rec.ID, &s); err == nil {
+		return s, true
+	}
+	return string(rec.ID), true
+}
+
+func jsonRecord(a Article) interface{} {
+	if a.Record != nil {
+		return a.Record
+	}
+	return a
+}
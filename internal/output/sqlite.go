@@ -0,0 +1,193 @@
+package output
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS articles (
+	source        TEXT NOT NULL,
+	board         TEXT NOT NULL,
+	id            TEXT NOT NULL,
+	title         TEXT,
+	writer        TEXT,
+	write_date    TEXT,
+	comment_count INTEGER,
+	read_count    INTEGER,
+	like_count    INTEGER,
+	url           TEXT,
+	content       TEXT,
+	raw_json      TEXT,
+	comment_count_mismatch INTEGER NOT NULL DEFAULT 0,
+	content_text     TEXT,
+	content_markdown TEXT,
+	deleted       INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (source, board, id)
+);
+CREATE TABLE IF NOT EXISTS comments (
+	source     TEXT NOT NULL,
+	board      TEXT NOT NULL,
+	article_id TEXT NOT NULL,
+	id         TEXT NOT NULL,
+	writer     TEXT,
+	write_date TEXT,
+	like_count INTEGER,
+	content    TEXT,
+	parent_id  TEXT,
+	depth      INTEGER NOT NULL DEFAULT 0,
+	deleted    INTEGER NOT NULL DEFAULT 0,
+	secret     INTEGER NOT NULL DEFAULT 0,
+	sticker    TEXT,
+	image      TEXT,
+	PRIMARY KEY (source, board, article_id, id)
+);
+`
+
+// SQLiteSink 는 게시글과 댓글을 SQLite 데이터베이스의 articles / comments 테이블에 기록합니다.
+// 같은 게시글/댓글을 다시 기록하면 최신 내용으로 교체됩니다.
+type SQLiteSink struct {
+	filename string
+
+	mu sync.Mutex
+	db *sql.DB
+	tx *sql.Tx
+}
+
+// NewSQLiteSink 는 filename 데이터베이스에 기록하는 SQLiteSink 를 생성합니다.
+func NewSQLiteSink(filename string) *SQLiteSink {
+	return &SQLiteSink{filename: filename}
+}
+
+// Open 은 데이터베이스를 열고 테이블을 준비합니다.
+func (s *SQLiteSink) Open() error {
+	if err := os.MkdirAll(filepath.Dir(s.filename), 0755); err != nil {
+		return fmt.Errorf("디렉토리 생성 실패: %v", err)
+	}
+	db, err := sql.Open("sqlite3", s.filename)
+	if err != nil {
+		return fmt.Errorf("데이터베이스 열기 실패: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return fmt.Errorf("테이블 생성 실패: %v", err)
+	}
+	s.db = db
+	return s.beginLocked()
+}
+
+func (s *SQLiteSink) beginLocked() error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	s.tx = tx
+	return nil
+}
+
+// WriteArticle 은 게시글 한 행을 기록합니다.
+func (s *SQLiteSink) WriteArticle(a Article) error {
+	raw, err := json.Marshal(jsonRecord(a))
+	if err != nil {
+		return fmt.Errorf("JSON 변환 실패: %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.tx.Exec(`INSERT OR REPLACE INTO articles
+		(source, board, id, title, writer, write_date, comment_count, read_count, like_count, url, content, raw_json,
+		 comment_count_mismatch, content_text, content_markdown, deleted)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		a.Source, a.Board, a.ID, a.Title, a.Writer, a.WriteDate,
+		a.CommentCount, a.ReadCount, a.LikeCount, a.URL, a.Content, string(raw),
+		a.CommentCountMismatch, a.ContentText, a.ContentMarkdown, a.Deleted)
+	if err != nil {
+		return fmt.Errorf("게시글 기록 실패: %v", err)
+	}
+	return nil
+}
+
+// WriteComment 는 댓글 한 행을 기록합니다.
+func (s *SQLiteSink) WriteComment(c Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.tx.Exec(`INSERT OR REPLACE INTO comments
+		(source, board, article_id, id, writer, write_date, like_count, content,
+		 parent_id, depth, deleted, secret, sticker, image)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		c.Source, c.Board, c.ArticleID, c.ID, c.Writer, c.WriteDate, c.LikeCount, c.Content,
+		nullIfEmpty(c.ParentID), c.Depth, c.Deleted, c.Secret, nullIfEmpty(c.Sticker), nullIfEmpty(c.Image))
+	if err != nil {
+		return fmt.Errorf("댓글 기록 실패: %v", err)
+	}
+	return nil
+}
+
+// 빈 문자열은 NULL 로 기록
+func nullIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// Sync 는 지금까지의 기록을 커밋합니다.
+func (s *SQLiteSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.tx.Commit(); err != nil {
+		return fmt.Errorf("커밋 실패: %v", err)
+	}
+	return s.beginLocked()
+}
+
+// Close 는 남은 기록을 커밋하고 데이터베이스를 닫습니다.
+func (s *SQLiteSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.db == nil {
+		return nil
+	}
+	err := s.tx.Commit()
+	if closeErr := s.db.Close(); err == nil {
+		err = closeErr
+	}
+	s.db, s.tx = nil, nil
+	return err
+}
+
+// ArticleIDs 는 데이터베이스에 기록된 게시글 ID 를 반환합니다.
+func (s *SQLiteSink) ArticleIDs() (map[string]bool, error) {
+	ids := make(map[string]bool)
+	if _, err := os.Stat(s.filename); os.IsNotExist(err) {
+		return ids, nil
+	}
+
+	db, err := sql.Open("sqlite3", s.filename)
+	if err != nil {
+		return nil, fmt.Errorf("데이터베이스 열기 실패: %v", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT id FROM articles`)
+	if err != nil {
+		// 테이블이 아직 없으면 기록된 게시글이 없는 것
+		return ids, nil
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids[id] = true
+	}
+	return ids, rows.Err()
+}
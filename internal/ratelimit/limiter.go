@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Limiter 는 여러 고루틴이 공유하는 토큰 버킷 기반 요청 속도 제한기입니다.
+// 전체 요청 속도 상한과 선택적인 호스트별 상한을 함께 적용합니다.
+type Limiter struct {
+	global *rate.Limiter
+	jitter time.Duration
+
+	hostLimit rate.Limit
+	hostBurst int
+	mu        sync.Mutex
+	hosts     map[string]*rate.Limiter
+}
+
+// Option 은 Limiter 설정을 변경하는 함수입니다.
+type Option func(*Limiter)
+
+// New 는 초당 rps 개, 최대 burst 개까지 몰아서 허용하는 Limiter 를 생성합니다.
+// rps 가 0 이하면 전체 속도를 제한하지 않습니다.
+func New(rps float64, burst int, opts ...Option) *Limiter {
+	l := &Limiter{
+		global: rate.NewLimiter(toLimit(rps), normalizeBurst(burst)),
+		hosts:  make(map[string]*rate.Limiter),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// WithJitter 는 토큰을 얻은 뒤 0~d 사이의 무작위 지연을 추가합니다.
+func WithJitter(d time.Duration) Option {
+	return func(l *Limiter) {
+		l.jitter = d
+	}
+}
+
+// WithPerHost 는 호스트마다 별도의 속도 상한을 추가로 적용합니다.
+// rps 가 0 이하면 호스트별로 제한하지 않습니다.
+func WithPerHost(rps float64, burst int) Option {
+	return func(l *Limiter) {
+		if rps <= 0 {
+			l.hostLimit = 0
+			return
+		}
+		l.hostLimit = rate.Limit(rps)
+		l.hostBurst = normalizeBurst(burst)
+	}
+}
+
+// Wait 는 host 로 요청을 보내도 될 때까지 대기합니다.
+// ctx 가 취소되면 즉시 ctx.Err() 를 반환합니다.
+func (l *Limiter) Wait(ctx context.Context, host string) error {
+	if l == nil {
+		return ctx.Err()
+	}
+	if err := l.global.Wait(ctx); err != nil {
+		return err
+	}
+	if hl := l.hostLimiter(host); hl != nil {
+		if err := hl.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	if l.jitter <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(rand.Int63n(int64(l.jitter))))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *Limiter) hostLimiter(host string) *rate.Limiter {
+	if l.hostLimit == 0 || host == "" {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	hl, ok := l.hosts[host]
+	if !ok {
+		hl = rate.NewLimiter(l.hostLimit, l.hostBurst)
+		l.hosts[host] = hl
+	}
+	return hl
+}
+
+func toLimit(rps float64) rate.Limit {
+	if rps <= 0 {
+		return rate.Inf
+	}
+	return rate.Limit(rps)
+}
+
+func normalizeBurst(burst int) int {
+	if burst < 1 {
+		return 1
+	}
+	return burst
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestWithPerHost(t *testing.T) {
+	tests := []struct {
+		name      string
+		rps       float64
+		burst     int
+		wantLimit rate.Limit // 0 이면 호스트별 제한 없음
+		wantBurst int
+	}{
+		{"제한 없음 (0)", 0, 1, 0, 0},
+		{"제한 없음 (음수)", -1, 3, 0, 0},
+		{"초당 2개", 2, 3, 2, 3},
+		{"burst 0 은 1", 0.5, 0, 0.5, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New(0, 1, WithPerHost(tt.rps, tt.burst))
+			hl := l.hostLimiter("cafe.naver.com")
+			if tt.wantLimit == 0 {
+				if hl != nil {
+					t.Fatalf("hostLimiter() = %v, want nil", hl.Limit())
+				}
+				return
+			}
+			if hl == nil {
+				t.Fatal("hostLimiter() = nil")
+			}
+			if hl.Limit() != tt.wantLimit || hl.Burst() != tt.wantBurst {
+				t.Errorf("hostLimiter() = (%v, %d), want (%v, %d)", hl.Limit(), hl.Burst(), tt.wantLimit, tt.wantBurst)
+			}
+			if l.hostLimiter("cafe.naver.com") != hl {
+				t.Error("같은 호스트에 다른 제한기를 만들었습니다")
+			}
+			if l.hostLimiter("blog.naver.com") == hl {
+				t.Error("다른 호스트가 제한기를 공유합니다")
+			}
+		})
+	}
+}
+
+func TestWaitPerHost(t *testing.T) {
+	// 전체 제한 없이 호스트마다 초당 10개: 같은 호스트 두 번째 요청만 기다림
+	l := New(0, 1, WithPerHost(10, 1))
+	ctx := context.Background()
+
+	start := time.Now()
+	for _, host := range []string{"a.example", "b.example", "c.example"} {
+		if err := l.Wait(ctx, host); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d > 50*time.Millisecond {
+		t.Errorf("서로 다른 호스트 요청이 %s 기다렸습니다", d)
+	}
+
+	start = time.Now()
+	if err := l.Wait(ctx, "a.example"); err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < 50*time.Millisecond {
+		t.Errorf("같은 호스트 요청이 %s 만 기다렸습니다, want 약 100ms", d)
+	}
+}
@@ -0,0 +1,371 @@
+// Package runner 는 설정 파일에 선언된 크롤링 작업을 실행합니다.
+// 모든 작업은 하나의 요청 속도 제한기를 공유합니다.
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"naverCafeCrawler/internal/config"
+	"naverCafeCrawler/internal/crawling"
+	"naverCafeCrawler/internal/credstore"
+	"naverCafeCrawler/internal/output"
+	"naverCafeCrawler/internal/ratelimit"
+	"naverCafeCrawler/internal/session"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// 블로그 작업에서 max_pages 를 생략했을 때 크롤링할 페이지 수
+const defaultBlogPages = 2
+
+// Result 는 작업 한 번의 실행 결과입니다.
+type Result struct {
+	Job       string
+	Type      string
+	StartedAt time.Time
+	Duration  time.Duration
+	Articles  int // 수집한 게시글 수 (stream_only 작업은 0)
+	Err       error
+}
+
+// Status 는 결과를 짧은 문자열로 나타냅니다.
+func (r Result) Status() string {
+	switch {
+	case r.Err == nil:
+		return "성공"
+	case errors.Is(r.Err, context.Canceled):
+		return "중단"
+	default:
+		return "실패"
+	}
+}
+
+// Runner 는 설정 파일의 작업을 실행합니다.
+type Runner struct {
+	cfg     *config.Config
+	limiter *ratelimit.Limiter
+	retry   crawling.RetryPolicy
+	opts    []crawling.Option
+
+	// 쿠키 이름별 로그인 세션과 세션 풀 계정. 작업과 반복 실행이 공유하므로
+	// 서버가 갱신한 쿠키, 세션별 요청 예산, 격리 상태가 이어집니다.
+	mu       sync.Mutex
+	sessions map[string]*session.Session
+	accounts map[string]*session.Account
+	store    *credstore.Store // 처음 store 쿠키를 사용할 때 엶
+}
+
+// New 는 cfg 의 작업을 실행하는 Runner 를 생성합니다.
+// opts 는 모든 작업의 크롤러에 추가로 적용됩니다.
+func New(cfg *config.Config, opts ...crawling.Option) *Runner {
+	return &Runner{
+		cfg:      cfg,
+		limiter:  newLimiter(cfg.RateLimit, ratelimit.WithJitter(crawling.DefaultJitter)),
+		retry:    retryPolicy(cfg.Retry),
+		opts:     opts,
+		sessions: make(map[string]*session.Session),
+		accounts: make(map[string]*session.Account),
+	}
+}
+
+// 설정의 rate_limit 으로 속도 제한기 생성
+func newLimiter(l config.RateLimit, opts ...ratelimit.Option) *ratelimit.Limiter {
+	opts = append(opts, ratelimit.WithPerHost(l.PerHost, l.Burst))
+	return ratelimit.New(l.RPS, l.Burst, opts...)
+}
+
+// 설정의 retry 를 기본 재시도 정책에 덮어씀
+func retryPolicy(r config.Retry) crawling.RetryPolicy {
+	p := crawling.DefaultRetryPolicy
+	if r.Retries != nil {
+		p.MaxAttempts = *r.Retries + 1
+	}
+	if r.BaseDelay > 0 {
+		p.BaseDelay = time.Duration(r.BaseDelay)
+	}
+	if r.MaxDelay > 0 {
+		p.MaxDelay = time.Duration(r.MaxDelay)
+	}
+	return p
+}
+
+// RunOnce 는 모든 작업을 한 번씩 실행하고 설정 순서대로 결과를 반환합니다.
+// 동시에 실행하는 작업 수는 설정의 parallel 값을 따릅니다.
+func (r *Runner) RunOnce(ctx context.Context) []Result {
+	results := make([]Result, len(r.cfg.Jobs))
+	var eg errgroup.Group
+	eg.SetLimit(r.cfg.Parallel)
+	for i, job := range r.cfg.Jobs {
+		i, job := i, job
+		eg.Go(func() error {
+			results[i] = r.RunJob(ctx, job)
+			return nil
+		})
+	}
+	eg.Wait()
+	return results
+}
+
+// Loop 는 schedule.every 가 지정된 작업을 주기적으로 반복 실행합니다.
+// 주기가 없는 작업은 한 번만 실행합니다. 실행이 끝날 때마다 report 를 호출하며,
+// ctx 가 취소되거나 반복할 작업이 없으면 반환합니다.
+func (r *Runner) Loop(ctx context.Context, report func(Result)) {
+	sem := make(chan struct{}, r.cfg.Parallel)
+	var eg errgroup.Group
+	for _, job := range r.cfg.Jobs {
+		job := job
+		eg.Go(func() error {
+			for {
+				select {
+				case sem <- struct{}{}:
+				case <-ctx.Done():
+					return nil
+				}
+				result := r.RunJob(ctx, job)
+				<-sem
+				report(result)
+
+				every := time.Duration(job.Schedule.Every)
+				if every <= 0 || ctx.Err() != nil {
+					return nil
+				}
+				log.Printf("⏰ 작업 %s 다음 실행: %s", job.Name, time.Now().Add(every).Format("2006-01-02 15:04:05"))
+				select {
+				case <-time.After(every):
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		})
+	}
+	eg.Wait()
+}
+
+// RunJob 은 작업 하나를 실행합니다.
+func (r *Runner) RunJob(ctx context.Context, job config.Job) Result {
+	result := Result{Job: job.Name, Type: job.Type, StartedAt: time.Now()}
+	log.Printf("▶️ 작업 %s (%s) 시작", job.Name, job.Type)
+
+	result.Articles, result.Err = r.runJob(ctx, job)
+	result.Duration = time.Since(result.StartedAt)
+
+	if result.Err != nil {
+		log.Printf("❌ 작업 %s %s: %v", job.Name, result.Status(), result.Err)
+	} else {
+		log.Printf("✅ 작업 %s 완료 (%d개 게시글, %s)", job.Name, result.Articles, result.Duration.Round(time.Second))
+	}
+	return result
+}
+
+func (r *Runner) runJob(ctx context.Context, job config.Job) (int, error) {
+	crawler, err := r.crawler(job)
+	if err != nil {
+		return 0, err
+	}
+	defer r.saveSessions(job)
+
+	if job.Type != config.JobBlog {
+		// 블로그는 로그인 없이 크롤링하므로 카페 작업만 로그인 상태 확인
+		if err := crawler.CheckSession(ctx); err != nil {
+			return 0, err
+		}
+		// cafe_id 에 별칭이나 주소를 넣은 경우 숫자 카페 ID 로 변환
+		ref, err := crawler.ResolveCafeURL(ctx, job.CafeID)
+		if err != nil {
+			return 0, err
+		}
+		job.CafeID = ref.CafeID
+		if job.BoardID == "" {
+			job.BoardID = ref.MenuID
+		}
+	}
+
+	switch job.Type {
+	case config.JobCafeBoard:
+		posts, err := crawler.CrawlBoard(ctx, job.CafeID, job.BoardID, crawling.BoardOptions{
+			MaxPages:    job.MaxPages,
+			PageSize:    job.PageSize,
+			Resume:      job.Resume,
+			StreamOnly:  job.StreamOnly,
+			Incremental: job.Incremental,
+		})
+		return len(posts), err
+	case config.JobCafeSearch:
+		posts, err := crawler.CrawlSearch(ctx, job.CafeID, job.Query, crawling.SearchOptions{
+			MaxPages:   job.MaxPages,
+			PageSize:   job.PageSize,
+			StreamOnly: job.StreamOnly,
+		})
+		return len(posts), err
+	case config.JobCafe:
+		results, err := crawler.CrawlCafe(ctx, job.CafeID, crawling.CafeOptions{
+			BoardOptions: crawling.BoardOptions{
+				MaxPages:    job.MaxPages,
+				PageSize:    job.PageSize,
+				Resume:      job.Resume,
+				StreamOnly:  job.StreamOnly,
+				Incremental: job.Incremental,
+			},
+			Include: job.Include,
+			Exclude: job.Exclude,
+		})
+		articles := 0
+		for _, r := range results {
+			articles += r.Articles
+		}
+		return articles, err
+	case config.JobBlog:
+		maxPages := job.MaxPages
+		if maxPages <= 0 {
+			maxPages = defaultBlogPages
+		}
+		posts, err := crawler.CrawlBlog(ctx, job.BlogID, maxPages)
+		return len(posts), err
+	default:
+		return 0, fmt.Errorf("알 수 없는 작업 종류: %s", job.Type)
+	}
+}
+
+// 작업 설정으로 크롤러 생성 (속도 제한기는 모든 작업이 공유, 재시도 정책은 설정의 retry)
+func (r *Runner) crawler(job config.Job) (*crawling.Crawler, error) {
+	opts := append([]crawling.Option{crawling.WithRateLimiter(r.limiter), crawling.WithRetryPolicy(r.retry)}, r.opts...)
+
+	switch {
+	case len(job.Cookies) > 0:
+		var accounts []*session.Account
+		for _, name := range job.Cookies {
+			a, err := r.account(name)
+			if err != nil {
+				return nil, err
+			}
+			accounts = append(accounts, a)
+		}
+		opts = append(opts, crawling.WithSessionPool(session.NewPool(accounts...)))
+	case job.Cookie != "":
+		sess, err := r.session(job.Cookie)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, crawling.WithSession(sess))
+	}
+	if job.Output.Dir != "" {
+		if job.Type == config.JobBlog {
+			opts = append(opts, crawling.WithBlogOutputDir(job.Output.Dir))
+		} else {
+			opts = append(opts, crawling.WithOutputDir(job.Output.Dir))
+		}
+	}
+	if job.Output.Format != "" {
+		format, err := output.ParseFormat(job.Output.Format)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, crawling.WithOutputFormat(format))
+	}
+	opts = append(opts, crawling.WithConcurrency(job.Concurrency), crawling.WithMediaDir(job.Output.MediaDir))
+	return crawling.New(opts...), nil
+}
+
+// 쿠키 이름의 로그인 세션 (처음 사용할 때 쿠키를 읽음)
+func (r *Runner) session(name string) (*session.Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessionLocked(name)
+}
+
+func (r *Runner) sessionLocked(name string) (*session.Session, error) {
+	if s, ok := r.sessions[name]; ok {
+		return s, nil
+	}
+	src := r.cfg.Cookies[name]
+	if src.Store != "" && r.store == nil {
+		store, err := r.cfg.Store.Open()
+		if err != nil {
+			return nil, err
+		}
+		r.store = store
+	}
+	s, err := src.Session(r.store)
+	if err != nil {
+		return nil, fmt.Errorf("쿠키 %s 읽기 실패: %v", name, err)
+	}
+	r.sessions[name] = s
+	return s, nil
+}
+
+// 쿠키 이름의 세션 풀 계정
+// 쿠키 항목에 rate_limit 이 있으면 전체 rate_limit 에 더해 그 계정의 요청 속도를 따로 제한합니다.
+func (r *Runner) account(name string) (*session.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if a, ok := r.accounts[name]; ok {
+		return a, nil
+	}
+	s, err := r.sessionLocked(name)
+	if err != nil {
+		return nil, err
+	}
+	src := r.cfg.Cookies[name]
+	cafes := src.Cafes
+	if len(cafes) == 0 && src.Store != "" {
+		// 설정에 없으면 'navercrawl auth add -cafes' 로 저장한 카페
+		entry, _ := r.store.Get(src.Store)
+		cafes = entry.Cafes
+	}
+	a := &session.Account{Name: name, Session: s, Cafes: cafes}
+	if src.RateLimit != nil {
+		a.Limiter = newLimiter(*src.RateLimit)
+	}
+	r.accounts[name] = a
+	return a, nil
+}
+
+// 작업이 사용한 세션 중 서버가 갱신한 쿠키를 세션 저장소나 cookies_file 에 다시 기록
+func (r *Runner) saveSessions(job config.Job) {
+	for _, name := range append([]string{job.Cookie}, job.Cookies...) {
+		r.mu.Lock()
+		s, store := r.sessions[name], r.store
+		r.mu.Unlock()
+		if s == nil {
+			continue
+		}
+		var (
+			saved bool
+			err   error
+			dest  = s.Path()
+		)
+		if src := r.cfg.Cookies[name]; src.Store != "" {
+			saved, err = store.Update(src.Store, s)
+			dest = store.Path()
+		} else {
+			saved, err = s.SaveIfChanged()
+		}
+		if err != nil {
+			log.Printf("⚠️ 쿠키 %s 저장 실패: %v", name, err)
+		} else if saved {
+			log.Printf("🍪 갱신된 쿠키 %s 를 %s 에 저장했습니다.", name, dest)
+		}
+	}
+}
+
+// PrintSummary 는 작업별 실행 결과를 표로 출력합니다.
+func PrintSummary(w io.Writer, results []Result) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "작업\t종류\t상태\t게시글\t소요 시간\t오류")
+	for _, r := range results {
+		errMsg := ""
+		if r.Err != nil {
+			errMsg = r.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\t%s\n",
+			r.Job, r.Type, r.Status(), r.Articles, r.Duration.Round(time.Second), errMsg)
+	}
+	tw.Flush()
+}
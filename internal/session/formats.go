@@ -0,0 +1,162 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HttpOnly 쿠키는 cookies.txt 에서 도메인 앞에 이 접두사를 붙입니다 (curl, yt-dlp 등).
+const httpOnlyPrefix = "#HttpOnly_"
+
+// Netscape cookies.txt 파싱
+// 한 줄에 쿠키 하나: 도메인, 하위 도메인 포함(TRUE/FALSE), 경로, HTTPS 전용(TRUE/FALSE), 만료(유닉스 초, 0 은 세션 쿠키), 이름, 값
+func parseNetscape(data string) ([]*http.Cookie, error) {
+	var cookies []*http.Cookie
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		httpOnly := false
+		if strings.HasPrefix(line, httpOnlyPrefix) {
+			line = strings.TrimPrefix(line, httpOnlyPrefix)
+			httpOnly = true
+		}
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) < 7 {
+			fields = strings.Fields(line)
+		}
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("%d번째 줄: 필드가 부족합니다", i+1)
+		}
+		value := ""
+		if len(fields) >= 7 {
+			value = strings.Join(fields[6:], "\t")
+		}
+		expiry, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%d번째 줄: 잘못된 만료 시각 %q", i+1, fields[4])
+		}
+
+		c := &http.Cookie{
+			Name:     fields[5],
+			Value:    value,
+			Domain:   cookieDomain(fields[0], !strings.EqualFold(fields[1], "TRUE")),
+			Path:     fields[2],
+			Secure:   strings.EqualFold(fields[3], "TRUE"),
+			HttpOnly: httpOnly,
+		}
+		if expiry > 0 {
+			c.Expires = time.Unix(expiry, 0)
+		}
+		cookies = append(cookies, c)
+	}
+	return cookies, nil
+}
+
+// Netscape cookies.txt 형식으로 변환
+func formatNetscape(cookies []*http.Cookie) string {
+	var b strings.Builder
+	b.WriteString("# Netscape HTTP Cookie File\n")
+	for _, c := range cookies {
+		domain := c.Domain
+		if c.HttpOnly {
+			domain = httpOnlyPrefix + domain
+		}
+		var expiry int64
+		if !c.Expires.IsZero() {
+			expiry = c.Expires.Unix()
+		}
+		fmt.Fprintf(&b, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain, netscapeBool(strings.HasPrefix(c.Domain, ".")), c.Path, netscapeBool(c.Secure),
+			expiry, c.Name, c.Value)
+	}
+	return b.String()
+}
+
+func netscapeBool(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+// 브라우저 확장 프로그램이 내보낸 쿠키 하나
+// EditThisCookie, Cookie-Editor 는 expirationDate, Playwright/Puppeteer 는 expires(-1 은 세션 쿠키)를 사용합니다.
+type browserCookie struct {
+	Name           string   `json:"name"`
+	Value          string   `json:"value"`
+	Domain         string   `json:"domain"`
+	Path           string   `json:"path"`
+	Secure         bool     `json:"secure"`
+	HTTPOnly       bool     `json:"httpOnly"`
+	HostOnly       *bool    `json:"hostOnly"`
+	Session        bool     `json:"session"`
+	ExpirationDate *float64 `json:"expirationDate"`
+	Expires        *float64 `json:"expires"`
+}
+
+// 브라우저 JSON 내보내기 파싱 (쿠키 배열 또는 {"cookies": [...]})
+func parseBrowserJSON(data []byte) ([]*http.Cookie, error) {
+	var list []browserCookie
+	if err := json.Unmarshal(data, &list); err != nil {
+		var wrapped struct {
+			Cookies []browserCookie `json:"cookies"`
+		}
+		if err2 := json.Unmarshal(data, &wrapped); err2 != nil {
+			return nil, err
+		}
+		list = wrapped.Cookies
+	}
+
+	cookies := make([]*http.Cookie, 0, len(list))
+	for _, bc := range list {
+		if bc.Name == "" || bc.Domain == "" {
+			continue
+		}
+		// hostOnly 가 없으면 도메인 앞의 "." 으로 판단
+		hostOnly := !strings.HasPrefix(bc.Domain, ".")
+		if bc.HostOnly != nil {
+			hostOnly = *bc.HostOnly
+		}
+		c := &http.Cookie{
+			Name:     bc.Name,
+			Value:    bc.Value,
+			Domain:   cookieDomain(bc.Domain, hostOnly),
+			Path:     bc.Path,
+			Secure:   bc.Secure,
+			HttpOnly: bc.HTTPOnly,
+		}
+		if !bc.Session {
+			c.Expires = unixSeconds(bc.ExpirationDate, bc.Expires)
+		}
+		cookies = append(cookies, c)
+	}
+	return cookies, nil
+}
+
+// 소수점이 있는 유닉스 초 (0 이하는 세션 쿠키)
+func unixSeconds(values ...*float64) time.Time {
+	for _, v := range values {
+		if v != nil && *v > 0 {
+			sec, frac := math.Modf(*v)
+			return time.Unix(int64(sec), int64(frac*1e9))
+		}
+	}
+	return time.Time{}
+}
+
+// 하위 도메인에도 보내는 쿠키는 "." 으로 시작하는 도메인, 호스트 전용 쿠키는 호스트 이름
+func cookieDomain(domain string, hostOnly bool) string {
+	domain = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "."))
+	if hostOnly {
+		return domain
+	}
+	return "." + domain
+}
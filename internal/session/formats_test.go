@@ -0,0 +1,141 @@
+package session
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseNetscape(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []http.Cookie
+		wantErr string
+	}{
+		{
+			name: "탭 구분",
+			data: "# Netscape HTTP Cookie File\n\n.naver.com\tTRUE\t/\tTRUE\t1893456000\tNID_AUT\taut\r\n",
+			want: []http.Cookie{{Name: "NID_AUT", Value: "aut", Domain: ".naver.com", Path: "/", Secure: true, Expires: time.Unix(1893456000, 0)}},
+		},
+		{
+			name: "HttpOnly 접두사",
+			data: "#HttpOnly_.naver.com\tTRUE\t/\tFALSE\t0\tNID_SES\tses\n",
+			want: []http.Cookie{{Name: "NID_SES", Value: "ses", Domain: ".naver.com", Path: "/", HttpOnly: true}},
+		},
+		{
+			name: "호스트 전용, 공백 구분",
+			data: "Cafe.Naver.com FALSE /ca-fe FALSE 0 ncvid v1\n",
+			want: []http.Cookie{{Name: "ncvid", Value: "v1", Domain: "cafe.naver.com", Path: "/ca-fe"}},
+		},
+		{
+			name: "빈 값",
+			data: ".naver.com\tTRUE\t/\tFALSE\t0\tempty\n",
+			want: []http.Cookie{{Name: "empty", Domain: ".naver.com", Path: "/"}},
+		},
+		{
+			name:    "필드 부족",
+			data:    "# comment\n.naver.com\tTRUE\t/\tFALSE\t0\n",
+			wantErr: "2번째 줄",
+		},
+		{
+			name:    "잘못된 만료 시각",
+			data:    ".naver.com\tTRUE\t/\tFALSE\tsoon\tNID_AUT\taut\n",
+			wantErr: "만료 시각",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNetscape(tt.data)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseNetscape() error = %v, want %q 포함", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCookies(t, got, tt.want)
+		})
+	}
+}
+
+func TestParseBrowserJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []http.Cookie
+		wantErr bool
+	}{
+		{
+			name: "배열, expirationDate",
+			data: `[{"name":"NID_AUT","value":"aut","domain":".naver.com","path":"/","secure":true,"httpOnly":true,"hostOnly":false,"expirationDate":1893456000.5}]`,
+			want: []http.Cookie{{Name: "NID_AUT", Value: "aut", Domain: ".naver.com", Path: "/", Secure: true, HttpOnly: true,
+				Expires: time.Unix(1893456000, 5e8)}},
+		},
+		{
+			name: "cookies 객체, expires",
+			data: `{"cookies":[{"name":"NID_SES","value":"ses","domain":"naver.com","path":"/","expires":1893456000}]}`,
+			want: []http.Cookie{{Name: "NID_SES", Value: "ses", Domain: "naver.com", Path: "/", Expires: time.Unix(1893456000, 0)}},
+		},
+		{
+			name: "세션 쿠키",
+			data: `[{"name":"a","value":"1","domain":".naver.com","path":"/","session":true,"expirationDate":1893456000},
+				{"name":"b","value":"2","domain":".naver.com","path":"/","expires":-1}]`,
+			want: []http.Cookie{
+				{Name: "a", Value: "1", Domain: ".naver.com", Path: "/"},
+				{Name: "b", Value: "2", Domain: ".naver.com", Path: "/"},
+			},
+		},
+		{
+			name: "hostOnly 가 도메인보다 우선",
+			data: `[{"name":"a","value":"1","domain":".Cafe.naver.com","path":"/","hostOnly":true},
+				{"name":"b","value":"2","domain":"cafe.naver.com","path":"/","hostOnly":false}]`,
+			want: []http.Cookie{
+				{Name: "a", Value: "1", Domain: "cafe.naver.com", Path: "/"},
+				{Name: "b", Value: "2", Domain: ".cafe.naver.com", Path: "/"},
+			},
+		},
+		{
+			name: "이름이나 도메인 없는 항목 제외",
+			data: `[{"name":"","value":"1","domain":".naver.com"},{"name":"b","value":"2"}]`,
+			want: nil,
+		},
+		{
+			name:    "JSON 아님",
+			data:    `NID_AUT=aut`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseBrowserJSON([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseBrowserJSON() = %v, want error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			assertCookies(t, got, tt.want)
+		})
+	}
+}
+
+func assertCookies(t *testing.T, got []*http.Cookie, want []http.Cookie) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("쿠키 %d개, want %d개: %v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Name != w.Name || g.Value != w.Value || g.Domain != w.Domain || g.Path != w.Path ||
+			g.Secure != w.Secure || g.HttpOnly != w.HttpOnly || !g.Expires.Equal(w.Expires) {
+			t.Errorf("쿠키[%d] = %+v, want %+v", i, *g, w)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"naverCafeCrawler/internal/ratelimit"
+)
+
+// ErrNoAccount 는 풀에 요청을 보낼 수 있는 세션이 더 이상 없을 때 반환됩니다.
+var ErrNoAccount = errors.New("사용할 수 있는 세션이 없습니다")
+
+// Account 는 세션 풀에 속한 이름 붙은 로그인 세션입니다.
+type Account struct {
+	Name    string
+	Session *Session
+	// 이 세션 전용 요청 속도 제한기 (nil 이면 크롤러의 전체 속도 제한만 적용)
+	Limiter *ratelimit.Limiter
+	// 이 세션으로 접근할 수 있는 카페 ID (비어 있으면 모든 카페)
+	Cafes []string
+
+	mu          sync.Mutex
+	quarantined time.Time // 이 시각까지 사용하지 않음
+	expired     bool      // 로그인이 만료되어 다시 사용하지 않음
+	reason      error     // 마지막으로 격리된 이유
+}
+
+// CanAccess 는 이 세션이 cafeID 카페에 접근할 수 있는지 반환합니다. 빈 cafeID 는 모든 세션이 접근할 수 있습니다.
+func (a *Account) CanAccess(cafeID string) bool {
+	if cafeID == "" || len(a.Cafes) == 0 {
+		return true
+	}
+	for _, id := range a.Cafes {
+		if id == cafeID {
+			return true
+		}
+	}
+	return false
+}
+
+// Status 는 세션 상태를 짧은 문자열로 나타냅니다.
+func (a *Account) Status() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case a.expired:
+		return fmt.Sprintf("만료 (%v)", a.reason)
+	case time.Now().Before(a.quarantined):
+		return fmt.Sprintf("%s 까지 격리 (%v)", a.quarantined.Format("15:04:05"), a.reason)
+	default:
+		return "사용 가능"
+	}
+}
+
+// Quarantine 은 세션을 d 동안 사용하지 않도록 격리합니다. d 가 0 이하면 다시 사용하지 않습니다(로그인 만료).
+func (a *Account) Quarantine(d time.Duration, reason error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reason = reason
+	if d <= 0 {
+		a.expired = true
+		return
+	}
+	if until := time.Now().Add(d); until.After(a.quarantined) {
+		a.quarantined = until
+	}
+}
+
+// 사용할 수 있으면 0, 격리 중이면 남은 시간, 만료되었으면 -1
+func (a *Account) waitTime(now time.Time) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.expired {
+		return -1
+	}
+	if now.Before(a.quarantined) {
+		return a.quarantined.Sub(now)
+	}
+	return 0
+}
+
+// Pool 은 여러 로그인 세션에 요청을 나눠 보냅니다.
+// 카페마다 접근할 수 있는 세션을 차례로(라운드 로빈) 고르고,
+// 로그인 만료나 요청 제한으로 격리된 세션은 건너뜁니다. 여러 고루틴에서 동시에 사용할 수 있습니다.
+type Pool struct {
+	mu       sync.Mutex
+	accounts []*Account
+	next     int
+}
+
+// NewPool 은 accounts 로 세션 풀을 만듭니다.
+func NewPool(accounts ...*Account) *Pool {
+	return &Pool{accounts: accounts}
+}
+
+// Accounts 는 풀의 세션 목록을 반환합니다.
+func (p *Pool) Accounts() []*Account {
+	return p.accounts
+}
+
+// Acquire 는 cafeID 카페에 요청을 보낼 세션을 고릅니다. skip 이 true 를 반환하는 세션은 제외합니다.
+// 접근할 수 있는 세션이 모두 격리 중이면 가장 먼저 풀리는 세션을 기다리고,
+// 남은 세션이 없으면 ErrNoAccount 를 반환합니다.
+func (p *Pool) Acquire(ctx context.Context, cafeID string, skip func(*Account) bool) (*Account, error) {
+	for {
+		account, wait, err := p.pick(cafeID, skip)
+		if err != nil || account != nil {
+			return account, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// 사용할 수 있는 세션, 또는 모두 격리 중이면 기다릴 시간
+func (p *Pool) pick(cafeID string, skip func(*Account) bool) (*Account, time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var (
+		wait     time.Duration
+		eligible int
+	)
+	for i := range p.accounts {
+		idx := (p.next + i) % len(p.accounts)
+		a := p.accounts[idx]
+		if !a.CanAccess(cafeID) || (skip != nil && skip(a)) {
+			continue
+		}
+		eligible++
+		switch w := a.waitTime(now); {
+		case w == 0:
+			p.next = idx + 1
+			return a, 0, nil
+		case w > 0 && (wait == 0 || w < wait):
+			wait = w
+		}
+	}
+	if wait > 0 {
+		return nil, wait, nil
+	}
+	if eligible == 0 {
+		return nil, 0, fmt.Errorf("%w: 카페 %s 에 접근할 수 있는 세션이 없습니다", ErrNoAccount, cafeID)
+	}
+	return nil, 0, fmt.Errorf("%w: 카페 %s 에 접근할 수 있는 세션 %d개가 모두 만료되었습니다", ErrNoAccount, cafeID, eligible)
+}
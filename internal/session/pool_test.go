@@ -0,0 +1,173 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPoolAcquire(t *testing.T) {
+	errBlocked := errors.New("접근 차단")
+	tests := []struct {
+		name    string
+		cafes   map[string][]string // 세션 이름별 접근할 수 있는 카페
+		setup   func(accounts map[string]*Account)
+		cafeID  string
+		skip    string // 제외할 세션
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "차례로 선택",
+			want: []string{"a", "b", "c", "a", "b"},
+		},
+		{
+			name:   "카페 접근 권한",
+			cafes:  map[string][]string{"a": {"1"}, "b": {"2"}},
+			cafeID: "2",
+			want:   []string{"b", "c", "b", "c"},
+		},
+		{
+			name: "제외한 세션",
+			skip: "b",
+			want: []string{"a", "c", "a"},
+		},
+		{
+			name:  "만료된 세션",
+			setup: func(m map[string]*Account) { m["a"].Quarantine(0, errBlocked) },
+			want:  []string{"b", "c", "b"},
+		},
+		{
+			name:  "격리 중인 세션",
+			setup: func(m map[string]*Account) { m["b"].Quarantine(time.Hour, errBlocked) },
+			want:  []string{"a", "c", "a"},
+		},
+		{
+			name: "모두 만료",
+			setup: func(m map[string]*Account) {
+				for _, a := range m {
+					a.Quarantine(0, errBlocked)
+				}
+			},
+			wantErr: "세션 3개가 모두 만료되었습니다",
+		},
+		{
+			name:    "접근할 수 있는 세션 없음",
+			cafes:   map[string][]string{"a": {"1"}, "b": {"1"}, "c": {"1"}},
+			cafeID:  "2",
+			wantErr: "접근할 수 있는 세션이 없습니다",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			byName := make(map[string]*Account)
+			var accounts []*Account
+			for _, name := range []string{"a", "b", "c"} {
+				a := &Account{Name: name, Cafes: tt.cafes[name]}
+				byName[name] = a
+				accounts = append(accounts, a)
+			}
+			if tt.setup != nil {
+				tt.setup(byName)
+			}
+			pool := NewPool(accounts...)
+			skip := func(a *Account) bool { return a.Name == tt.skip }
+
+			if tt.wantErr != "" {
+				_, err := pool.Acquire(context.Background(), tt.cafeID, skip)
+				if !errors.Is(err, ErrNoAccount) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Acquire() error = %v, want ErrNoAccount (%s)", err, tt.wantErr)
+				}
+				return
+			}
+			var got []string
+			for range tt.want {
+				a, err := pool.Acquire(context.Background(), tt.cafeID, skip)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, a.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("선택한 세션 = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolAcquireWaitsForQuarantine(t *testing.T) {
+	errLimited := errors.New("요청 제한 초과")
+	a := &Account{Name: "a"}
+	b := &Account{Name: "b"}
+	a.Quarantine(50*time.Millisecond, errLimited)
+	b.Quarantine(time.Hour, errLimited)
+	pool := NewPool(a, b)
+
+	// 모두 격리 중이면 가장 먼저 풀리는 세션을 기다림
+	start := time.Now()
+	got, err := pool.Acquire(context.Background(), "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != a {
+		t.Errorf("Acquire() = %s, want a", got.Name)
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("격리가 풀리기 전에 %s 만에 반환했습니다", d)
+	}
+
+	// 기다리는 중에 ctx 가 취소되면 중단
+	a.Quarantine(time.Hour, errLimited)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := pool.Acquire(ctx, "", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Acquire() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestAccountQuarantine(t *testing.T) {
+	errLimited := errors.New("요청 제한 초과")
+	a := &Account{Name: "a"}
+	if got := a.Status(); got != "사용 가능" {
+		t.Errorf("Status() = %q, want 사용 가능", got)
+	}
+
+	// 더 짧은 격리는 이미 걸린 격리를 줄이지 않음
+	a.Quarantine(time.Hour, errLimited)
+	a.Quarantine(time.Millisecond, errLimited)
+	if w := a.waitTime(time.Now()); w < 59*time.Minute {
+		t.Errorf("waitTime() = %s, want 약 1시간", w)
+	}
+	if got := a.Status(); !strings.Contains(got, "격리") || !strings.Contains(got, errLimited.Error()) {
+		t.Errorf("Status() = %q", got)
+	}
+
+	a.Quarantine(0, errors.New("로그인 세션 만료"))
+	if w := a.waitTime(time.Now()); w != -1 {
+		t.Errorf("만료된 세션 waitTime() = %s, want -1", w)
+	}
+	if got := a.Status(); !strings.HasPrefix(got, "만료") {
+		t.Errorf("Status() = %q, want 만료", got)
+	}
+}
+
+func TestCanAccess(t *testing.T) {
+	tests := []struct {
+		cafes  []string
+		cafeID string
+		want   bool
+	}{
+		{nil, "1", true},
+		{[]string{"1", "2"}, "2", true},
+		{[]string{"1", "2"}, "3", false},
+		{[]string{"1"}, "", true},
+	}
+	for _, tt := range tests {
+		if got := (&Account{Cafes: tt.cafes}).CanAccess(tt.cafeID); got != tt.want {
+			t.Errorf("CanAccess(%v, %q) = %v, want %v", tt.cafes, tt.cafeID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+// Package session 은 네이버 로그인 쿠키를 http.CookieJar 로 관리합니다.
+//
+// 브라우저 확장 프로그램으로 내보낸 cookies.txt(Netscape 형식)나 JSON 파일,
+// 또는 요청 헤더에서 복사한 쿠키 문자열을 읽을 수 있습니다.
+// 서버가 응답으로 갱신한 쿠키는 이후 요청에 그대로 사용되며, cookies.txt 에서 읽은 세션은 파일에 다시 기록할 수 있습니다.
+package session
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// 로그인 여부를 판단하는 네이버 인증 쿠키
+const (
+	AuthCookie    = "NID_AUT"
+	SessionCookie = "NID_SES"
+)
+
+// DefaultExpiryWarning 은 인증 쿠키 만료가 이 시간 안으로 다가오면 경고하는 기준입니다.
+const DefaultExpiryWarning = 24 * time.Hour
+
+// Format 은 쿠키를 읽어 온 형식입니다.
+type Format string
+
+const (
+	FormatNetscape Format = "cookies.txt" // Netscape cookies.txt
+	FormatJSON     Format = "json"        // 브라우저 확장 프로그램의 JSON 내보내기
+	FormatHeader   Format = "header"      // "NID_AUT=...; NID_SES=..." 쿠키 헤더 문자열
+)
+
+// 로그인 쿠키가 전송되는 네이버 주소
+var naverURL = &url.URL{Scheme: "https", Host: "cafe.naver.com", Path: "/"}
+
+// Session 은 로그인 쿠키를 담은 http.CookieJar 입니다.
+// http.Client 의 Jar 로 지정하면 요청마다 쿠키를 보내고, 응답의 Set-Cookie 로 갱신된 쿠키를 보관합니다.
+// 여러 고루틴에서 동시에 사용할 수 있습니다.
+type Session struct {
+	jar    *cookiejar.Jar
+	path   string // 쿠키를 읽은 파일 (헤더 문자열이면 빈 문자열)
+	format Format
+
+	mu sync.Mutex
+	// 불러온 쿠키와 서버가 갱신한 쿠키 (도메인|경로|이름 → 쿠키)
+	// Domain 이 "." 으로 시작하면 하위 도메인에도 보내는 쿠키, 아니면 그 호스트에만 보내는 쿠키입니다.
+	cookies map[string]*http.Cookie
+	changed bool
+}
+
+// New 는 cookies 를 담은 Session 을 생성합니다.
+// 쿠키의 Domain 이 "." 으로 시작하면 하위 도메인 전체, 아니면 그 호스트에만 보냅니다.
+// 이미 만료된 쿠키는 무시합니다.
+func New(cookies []*http.Cookie) (*Session, error) {
+	jar, err := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{jar: jar, cookies: make(map[string]*http.Cookie)}
+	now := time.Now()
+	for _, c := range cookies {
+		if c.Name == "" || c.Domain == "" {
+			continue
+		}
+		if !c.Expires.IsZero() && c.Expires.Before(now) {
+			continue
+		}
+		host := strings.TrimPrefix(c.Domain, ".")
+		sent := *c
+		if !strings.HasPrefix(c.Domain, ".") {
+			sent.Domain = "" // 호스트 전용 쿠키
+		}
+		if sent.Path == "" {
+			sent.Path = "/"
+		}
+		jar.SetCookies(&url.URL{Scheme: "https", Host: host, Path: "/"}, []*http.Cookie{&sent})
+		stored := *c
+		stored.Path = sent.Path
+		s.cookies[cookieKey(&stored)] = &stored
+	}
+	return s, nil
+}
+
+// Load 는 path 의 쿠키 파일을 읽습니다. 형식은 Parse 와 같습니다.
+func Load(path string) (*Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("쿠키 파일 읽기 실패: %v", err)
+	}
+	s, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("쿠키 파일 %s: %v", path, err)
+	}
+	s.path = path
+	return s, nil
+}
+
+// Parse 는 쿠키 파일 내용을 읽습니다.
+// JSON 이면 브라우저 확장 프로그램(EditThisCookie, Cookie-Editor 등)이나 Playwright storageState 형식으로,
+// 아니면 Netscape cookies.txt 형식으로 읽습니다.
+func Parse(data []byte) (*Session, error) {
+	var (
+		cookies []*http.Cookie
+		format  Format
+		err     error
+	)
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") || strings.HasPrefix(trimmed, "{") {
+		format = FormatJSON
+		cookies, err = parseBrowserJSON([]byte(trimmed))
+	} else {
+		format = FormatNetscape
+		cookies, err = parseNetscape(trimmed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("파싱 실패: %v", err)
+	}
+	if len(cookies) == 0 {
+		return nil, fmt.Errorf("쿠키가 없습니다")
+	}
+
+	s, err := New(cookies)
+	if err != nil {
+		return nil, err
+	}
+	s.format = format
+	return s, nil
+}
+
+// FromHeader 는 브라우저 요청 헤더에서 복사한 쿠키 문자열("NID_AUT=...; NID_SES=...")로 Session 을 생성합니다.
+// 만료 시각을 알 수 없으므로 모든 쿠키를 .naver.com 의 세션 쿠키로 취급합니다.
+func FromHeader(raw string) (*Session, error) {
+	var cookies []*http.Cookie
+	for _, part := range strings.Split(raw, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		cookies = append(cookies, &http.Cookie{
+			Name:   strings.TrimSpace(name),
+			Value:  strings.TrimSpace(value),
+			Domain: ".naver.com",
+			Path:   "/",
+		})
+	}
+	if len(cookies) == 0 {
+		return nil, fmt.Errorf("쿠키 문자열에 쿠키가 없습니다")
+	}
+	s, err := New(cookies)
+	if err != nil {
+		return nil, err
+	}
+	s.format = FormatHeader
+	return s, nil
+}
+
+// SetCookies 는 http.CookieJar 를 구현합니다. 서버가 보낸 쿠키를 jar 에 반영하고 기록해 둡니다.
+func (s *Session) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	s.jar.SetCookies(u, cookies)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, c := range cookies {
+		stored := *c
+		if stored.Domain == "" {
+			stored.Domain = u.Hostname()
+		} else {
+			stored.Domain = "." + strings.TrimPrefix(stored.Domain, ".")
+		}
+		if stored.Path == "" {
+			stored.Path = "/"
+		}
+		if stored.MaxAge > 0 {
+			stored.Expires = now.Add(time.Duration(stored.MaxAge) * time.Second)
+		}
+		key := cookieKey(&stored)
+		if stored.MaxAge < 0 || (!stored.Expires.IsZero() && stored.Expires.Before(now)) {
+			delete(s.cookies, key)
+		} else {
+			s.cookies[key] = &stored
+		}
+		s.changed = true
+	}
+}
+
+// Cookies 는 http.CookieJar 를 구현합니다.
+func (s *Session) Cookies(u *url.URL) []*http.Cookie {
+	return s.jar.Cookies(u)
+}
+
+// LoggedIn 은 네이버 인증 쿠키(NID_AUT, NID_SES)가 모두 있는지 반환합니다.
+// 쿠키가 서버에서 유효한지는 확인하지 않습니다.
+func (s *Session) LoggedIn() bool {
+	var aut, ses bool
+	for _, c := range s.jar.Cookies(naverURL) {
+		switch c.Name {
+		case AuthCookie:
+			aut = c.Value != ""
+		case SessionCookie:
+			ses = c.Value != ""
+		}
+	}
+	return aut && ses
+}
+
+// Expires 는 인증 쿠키(NID_AUT, NID_SES) 중 가장 먼저 만료되는 시각을 반환합니다.
+// 브라우저를 닫으면 사라지는 세션 쿠키뿐이거나 만료 시각을 모르면 0 을 반환합니다.
+func (s *Session) Expires() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var earliest time.Time
+	for _, c := range s.cookies {
+		if c.Name != AuthCookie && c.Name != SessionCookie {
+			continue
+		}
+		if c.Expires.IsZero() {
+			continue
+		}
+		if earliest.IsZero() || c.Expires.Before(earliest) {
+			earliest = c.Expires
+		}
+	}
+	return earliest
+}
+
+// ExpiresWithin 은 인증 쿠키가 d 안에 만료되는지 반환합니다.
+func (s *Session) ExpiresWithin(d time.Duration) bool {
+	expires := s.Expires()
+	return !expires.IsZero() && time.Until(expires) < d
+}
+
+// Path 는 쿠키를 읽은 파일 경로입니다. 쿠키 문자열로 만든 Session 이면 빈 문자열입니다.
+func (s *Session) Path() string {
+	return s.path
+}
+
+// Format 은 쿠키를 읽어 온 형식입니다.
+func (s *Session) Format() Format {
+	return s.format
+}
+
+// Changed 는 불러온 뒤 서버가 쿠키를 갱신했는지 반환합니다.
+func (s *Session) Changed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changed
+}
+
+// Netscape 는 현재 쿠키(서버가 갱신한 쿠키 포함)를 Netscape cookies.txt 형식으로 반환합니다.
+func (s *Session) Netscape() string {
+	s.mu.Lock()
+	cookies := make([]*http.Cookie, 0, len(s.cookies))
+	for _, c := range s.cookies {
+		cookies = append(cookies, c)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(cookies, func(i, j int) bool {
+		return cookieKey(cookies[i]) < cookieKey(cookies[j])
+	})
+	return formatNetscape(cookies)
+}
+
+// MarkSaved 는 갱신된 쿠키를 기록했음을 표시합니다. 이후 Changed 는 다시 갱신될 때까지 false 입니다.
+func (s *Session) MarkSaved() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changed = false
+}
+
+// Save 는 현재 쿠키를 Netscape cookies.txt 형식으로 path 에 기록합니다.
+func (s *Session) Save(path string) error {
+	data := s.Netscape()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(data), 0600); err != nil {
+		return fmt.Errorf("쿠키 파일 저장 실패: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("쿠키 파일 저장 실패: %v", err)
+	}
+	s.MarkSaved()
+	return nil
+}
+
+// SaveIfChanged 는 cookies.txt 에서 읽은 세션의 쿠키가 갱신되었으면 같은 파일에 다시 기록하고 true 를 반환합니다.
+// JSON 내보내기나 쿠키 문자열로 만든 세션은 원본 형식을 보존하기 위해 기록하지 않습니다.
+func (s *Session) SaveIfChanged() (bool, error) {
+	if s.format != FormatNetscape || s.path == "" || !s.Changed() {
+		return false, nil
+	}
+	if err := s.Save(s.path); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func cookieKey(c *http.Cookie) string {
+	return c.Domain + "|" + c.Path + "|" + c.Name
+}